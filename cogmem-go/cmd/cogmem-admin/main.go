@@ -0,0 +1,78 @@
+// Command cogmem-admin runs the admin HTTP API backed by the configured
+// EpisodicRepository.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/lexlapax/cogmem/internal/domain/service/decay"
+	"github.com/lexlapax/cogmem/internal/infrastructure/config"
+	"github.com/lexlapax/cogmem/internal/infrastructure/persistence"
+	"github.com/lexlapax/cogmem/internal/infrastructure/persistence/metrics"
+	"github.com/lexlapax/cogmem/internal/interface/http/admin"
+)
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	if !cfg.Admin.Enabled {
+		return fmt.Errorf("admin server is disabled; set admin.enabled to run it")
+	}
+
+	ctx := context.Background()
+
+	var pool *pgxpool.Pool
+	if cfg.Storage.Backend == "" || cfg.Storage.Backend == "postgres" {
+		pool, err = pgxpool.New(ctx, cfg.DatabaseURL)
+		if err != nil {
+			return fmt.Errorf("connect to database: %w", err)
+		}
+		defer pool.Close()
+	}
+
+	repo, err := persistence.NewEpisodicRepository(cfg, pool)
+	if err != nil {
+		return fmt.Errorf("construct repository: %w", err)
+	}
+
+	var ping admin.PingFunc
+	if pool != nil {
+		ping = pool.Ping
+	}
+
+	if metricsRepo, ok := repo.(*metrics.Repository); ok && pool != nil {
+		monitor, err := persistence.NewAlarmMonitor(cfg, pool, metricsRepo)
+		if err != nil {
+			return fmt.Errorf("construct alarm monitor: %w", err)
+		}
+		go func() {
+			if err := monitor.Start(ctx); err != nil && ctx.Err() == nil {
+				fmt.Fprintf(os.Stderr, "alarm monitor stopped: %v\n", err)
+			}
+		}()
+	}
+
+	decayer := decay.New(repo, cfg.DecayBaseRate, cfg.DecayValenceWeight, cfg.DecayMinScore, cfg.DecayInterval, cfg.DecayBatchSize)
+	go func() {
+		if err := decayer.Start(ctx); err != nil && ctx.Err() == nil {
+			fmt.Fprintf(os.Stderr, "decay subsystem stopped: %v\n", err)
+		}
+	}()
+
+	server := admin.NewServer(repo, cfg.Admin.AuthToken, ping)
+	fmt.Printf("cogmem-admin listening on %s\n", cfg.Admin.ListenAddr)
+	return server.ListenAndServe(cfg.Admin.ListenAddr)
+}
@@ -0,0 +1,70 @@
+// Command cogmem-migrate applies and inspects CogMem's Postgres schema migrations.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/lexlapax/cogmem/internal/infrastructure/config"
+	"github.com/lexlapax/cogmem/internal/infrastructure/persistence/migrate"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: cogmem-migrate <up|down|to VERSION|status>")
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, cfg.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("connect to database: %w", err)
+	}
+	defer pool.Close()
+
+	switch args[0] {
+	case "up":
+		return migrate.Up(ctx, pool)
+	case "down":
+		return migrate.Down(ctx, pool)
+	case "to":
+		if len(args) != 2 {
+			return fmt.Errorf("usage: cogmem-migrate to VERSION")
+		}
+		version, err := strconv.Atoi(args[1])
+		if err != nil {
+			return fmt.Errorf("invalid version %q: %w", args[1], err)
+		}
+		return migrate.To(ctx, pool, version)
+	case "status":
+		entries, err := migrate.Status(ctx, pool)
+		if err != nil {
+			return err
+		}
+		for _, e := range entries {
+			state := "pending"
+			if e.Applied {
+				state = "applied"
+			}
+			fmt.Printf("%04d_%s: %s\n", e.Version, e.Name, state)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+}
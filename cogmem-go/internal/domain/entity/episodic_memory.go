@@ -26,6 +26,9 @@ type EpisodicMemory struct {
 	LastAccessed time.Time
 	// AccessibilityScore indicates current retrieval priority (default 1.0).
 	AccessibilityScore float64
+	// Valence is an optional emotional weighting applied by the decay subsystem
+	// when rescoring AccessibilityScore; nil is treated as 0.
+	Valence *float64
 }
 
 // NewEpisodicMemory constructs a new EpisodicMemory with default values.
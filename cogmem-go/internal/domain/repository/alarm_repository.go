@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AlarmType identifies a class of health alarm condition, modeled on the
+// alarm types raised by distributed key-value stores such as etcd.
+type AlarmType string
+
+const (
+	// AlarmNoSpace indicates storage capacity (row count or disk usage) has
+	// crossed its configured threshold; writes should be rejected until cleared.
+	AlarmNoSpace AlarmType = "NOSPACE"
+	// AlarmCorrupt indicates detected data corruption.
+	AlarmCorrupt AlarmType = "CORRUPT"
+	// AlarmDegraded indicates an elevated repository operation error rate.
+	AlarmDegraded AlarmType = "DEGRADED"
+)
+
+// AlarmLevel indicates how severely an alarm should degrade service.
+type AlarmLevel string
+
+const (
+	// LevelWarning alarms are informational and do not block operations.
+	LevelWarning AlarmLevel = "warning"
+	// LevelCritical alarms block the operations they guard until cleared.
+	LevelCritical AlarmLevel = "critical"
+)
+
+// AlarmMember is a single active alarm record; Deactivate removes it.
+type AlarmMember struct {
+	ID      uuid.UUID
+	Type    AlarmType
+	Level   AlarmLevel
+	Since   time.Time
+	Details string
+}
+
+// AlarmStore persists health alarm state, tracking which alarm types are
+// currently active so repository operations can consult it before proceeding.
+type AlarmStore interface {
+	// Activate raises an alarm of the given type at the given level, recording
+	// details. Activating an already-active alarm of the same type updates its
+	// Level and Details in place, leaving Since unchanged.
+	Activate(ctx context.Context, alarmType AlarmType, level AlarmLevel, details string) error
+	// Deactivate clears an alarm of the given type, if one is active.
+	Deactivate(ctx context.Context, alarmType AlarmType) error
+	// Get returns the active alarm of the given type, or nil if none is active.
+	Get(ctx context.Context, alarmType AlarmType) (*AlarmMember, error)
+	// List returns every currently active alarm.
+	List(ctx context.Context) ([]AlarmMember, error)
+}
@@ -8,6 +8,10 @@ import (
 	"github.com/lexlapax/cogmem/internal/domain/entity"
 )
 
+// PartitionBatchFunc processes one batch of memories belonging to a single partition,
+// as supplied by EpisodicRepository.IteratePartitions.
+type PartitionBatchFunc func(ctx context.Context, pCtx entity.PartitionContext, batch []*entity.EpisodicMemory) error
+
 // EpisodicRepository defines persistence operations for EpisodicMemory entities.
 type EpisodicRepository interface {
 	// Save persists a new memory item.
@@ -18,4 +22,13 @@ type EpisodicRepository interface {
 	FindByVector(ctx context.Context, vector []float32, limit int, pCtx entity.PartitionContext) ([]*entity.EpisodicMemory, error)
 	// FindRecent retrieves the most recent memories up to limit, within a partition.
 	FindRecent(ctx context.Context, limit int, pCtx entity.PartitionContext) ([]*entity.EpisodicMemory, error)
+	// UpdateAccessibility updates a memory's AccessibilityScore within a given partition.
+	UpdateAccessibility(ctx context.Context, id uuid.UUID, score float64, pCtx entity.PartitionContext) error
+	// Delete removes a memory by ID within a given partition, e.g. when the decay
+	// subsystem purges a memory that has fallen below its minimum score.
+	Delete(ctx context.Context, id uuid.UUID, pCtx entity.PartitionContext) error
+	// IteratePartitions walks every stored partition's memories in batches of batchSize,
+	// invoking fn once per batch. Used by the decay subsystem to rescore memories
+	// without loading an entire backend's contents into memory at once.
+	IteratePartitions(ctx context.Context, batchSize int, fn PartitionBatchFunc) error
 }
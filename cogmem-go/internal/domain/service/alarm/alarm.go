@@ -0,0 +1,129 @@
+// Package alarm periodically samples storage capacity and repository error
+// rate, raising or clearing health alarms in an AlarmStore accordingly.
+package alarm
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lexlapax/cogmem/internal/domain/repository"
+	"github.com/lexlapax/cogmem/internal/infrastructure/log"
+)
+
+// ErrAlarmActive is returned by repository operations that decline to proceed
+// because a blocking alarm of Type is active.
+type ErrAlarmActive struct {
+	Type repository.AlarmType
+}
+
+func (e ErrAlarmActive) Error() string {
+	return fmt.Sprintf("alarm %s is active", e.Type)
+}
+
+// CapacityProber reports current storage utilization, used by Monitor to
+// decide whether to raise or clear a NOSPACE alarm.
+type CapacityProber interface {
+	// RowCount returns the number of rows currently stored.
+	RowCount(ctx context.Context) (int64, error)
+	// DatabaseSizeBytes returns the total on-disk size of the database.
+	DatabaseSizeBytes(ctx context.Context) (int64, error)
+}
+
+// ErrorRateProber reports the current repository operation error rate, as a
+// fraction between 0 and 1, used by Monitor to decide whether to raise or
+// clear a DEGRADED alarm.
+type ErrorRateProber interface {
+	ErrorRate(ctx context.Context) (float64, error)
+}
+
+// Monitor runs on Interval ticks, sampling capacity and error-rate and
+// toggling NOSPACE and DEGRADED alarms in an AlarmStore accordingly.
+type Monitor struct {
+	store              repository.AlarmStore
+	capacity           CapacityProber
+	errorRate          ErrorRateProber
+	maxRows            int64
+	maxDatabaseBytes   int64
+	errorRateThreshold float64
+	interval           time.Duration
+	logger             *log.Logger
+}
+
+// New constructs a Monitor. maxRows and maxDatabaseBytes are NOSPACE
+// thresholds; a zero value disables the corresponding check.
+// errorRateThreshold is the DEGRADED threshold, as a fraction between 0 and 1.
+func New(store repository.AlarmStore, capacity CapacityProber, errorRate ErrorRateProber, maxRows, maxDatabaseBytes int64, errorRateThreshold float64, interval time.Duration) *Monitor {
+	return &Monitor{
+		store:              store,
+		capacity:           capacity,
+		errorRate:          errorRate,
+		maxRows:            maxRows,
+		maxDatabaseBytes:   maxDatabaseBytes,
+		errorRateThreshold: errorRateThreshold,
+		interval:           interval,
+		logger:             log.Default(),
+	}
+}
+
+// RunOnce samples capacity and error-rate once, toggling alarms as needed.
+// Exposed separately from Start for tests and one-shot invocations.
+func (m *Monitor) RunOnce(ctx context.Context) error {
+	if err := m.checkCapacity(ctx); err != nil {
+		return fmt.Errorf("check capacity: %w", err)
+	}
+	if err := m.checkErrorRate(ctx); err != nil {
+		return fmt.Errorf("check error rate: %w", err)
+	}
+	return nil
+}
+
+func (m *Monitor) checkCapacity(ctx context.Context) error {
+	rows, err := m.capacity.RowCount(ctx)
+	if err != nil {
+		return err
+	}
+	bytes, err := m.capacity.DatabaseSizeBytes(ctx)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case m.maxRows > 0 && rows > m.maxRows:
+		return m.store.Activate(ctx, repository.AlarmNoSpace, repository.LevelCritical,
+			fmt.Sprintf("row count %d exceeds alarm.max_rows %d", rows, m.maxRows))
+	case m.maxDatabaseBytes > 0 && bytes > m.maxDatabaseBytes:
+		return m.store.Activate(ctx, repository.AlarmNoSpace, repository.LevelCritical,
+			fmt.Sprintf("database size %d bytes exceeds alarm.max_database_bytes %d", bytes, m.maxDatabaseBytes))
+	default:
+		return m.store.Deactivate(ctx, repository.AlarmNoSpace)
+	}
+}
+
+func (m *Monitor) checkErrorRate(ctx context.Context) error {
+	rate, err := m.errorRate.ErrorRate(ctx)
+	if err != nil {
+		return err
+	}
+	if m.errorRateThreshold > 0 && rate > m.errorRateThreshold {
+		return m.store.Activate(ctx, repository.AlarmDegraded, repository.LevelWarning,
+			fmt.Sprintf("error rate %.2f exceeds alarm.error_rate_threshold %.2f", rate, m.errorRateThreshold))
+	}
+	return m.store.Deactivate(ctx, repository.AlarmDegraded)
+}
+
+// Start runs sampling passes on every Interval tick until ctx is canceled.
+func (m *Monitor) Start(ctx context.Context) error {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if err := m.RunOnce(ctx); err != nil {
+				m.logger.Error("alarm monitor pass failed", "error", err)
+			}
+		}
+	}
+}
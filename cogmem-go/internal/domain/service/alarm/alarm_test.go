@@ -0,0 +1,121 @@
+package alarm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/lexlapax/cogmem/internal/domain/repository"
+)
+
+// fakeAlarmStore is a minimal in-memory repository.AlarmStore used to exercise
+// Monitor without a real storage backend.
+type fakeAlarmStore struct {
+	active map[repository.AlarmType]repository.AlarmMember
+}
+
+func newFakeAlarmStore() *fakeAlarmStore {
+	return &fakeAlarmStore{active: map[repository.AlarmType]repository.AlarmMember{}}
+}
+
+func (s *fakeAlarmStore) Activate(ctx context.Context, alarmType repository.AlarmType, level repository.AlarmLevel, details string) error {
+	member, ok := s.active[alarmType]
+	if !ok {
+		member = repository.AlarmMember{ID: uuid.New(), Type: alarmType, Since: time.Now().UTC()}
+	}
+	member.Level = level
+	member.Details = details
+	s.active[alarmType] = member
+	return nil
+}
+
+func (s *fakeAlarmStore) Deactivate(ctx context.Context, alarmType repository.AlarmType) error {
+	delete(s.active, alarmType)
+	return nil
+}
+
+func (s *fakeAlarmStore) Get(ctx context.Context, alarmType repository.AlarmType) (*repository.AlarmMember, error) {
+	member, ok := s.active[alarmType]
+	if !ok {
+		return nil, nil
+	}
+	return &member, nil
+}
+
+func (s *fakeAlarmStore) List(ctx context.Context) ([]repository.AlarmMember, error) {
+	var members []repository.AlarmMember
+	for _, member := range s.active {
+		members = append(members, member)
+	}
+	return members, nil
+}
+
+var _ repository.AlarmStore = (*fakeAlarmStore)(nil)
+
+// fakeProbe reports fixed capacity and error-rate readings.
+type fakeProbe struct {
+	rows      int64
+	dbBytes   int64
+	errorRate float64
+}
+
+func (p *fakeProbe) RowCount(ctx context.Context) (int64, error)          { return p.rows, nil }
+func (p *fakeProbe) DatabaseSizeBytes(ctx context.Context) (int64, error) { return p.dbBytes, nil }
+func (p *fakeProbe) ErrorRate(ctx context.Context) (float64, error)       { return p.errorRate, nil }
+
+func TestMonitorRunOnceActivatesNoSpaceOverRowLimit(t *testing.T) {
+	store := newFakeAlarmStore()
+	probe := &fakeProbe{rows: 1000}
+	m := New(store, probe, probe, 100, 0, 0, time.Minute)
+
+	if err := m.RunOnce(context.Background()); err != nil {
+		t.Fatalf("RunOnce error: %v", err)
+	}
+
+	member, err := store.Get(context.Background(), repository.AlarmNoSpace)
+	if err != nil {
+		t.Fatalf("Get error: %v", err)
+	}
+	if member == nil {
+		t.Fatal("expected NOSPACE alarm to be active")
+	}
+}
+
+func TestMonitorRunOnceClearsNoSpaceUnderRowLimit(t *testing.T) {
+	store := newFakeAlarmStore()
+	_ = store.Activate(context.Background(), repository.AlarmNoSpace, repository.LevelCritical, "stale")
+	probe := &fakeProbe{rows: 10}
+	m := New(store, probe, probe, 100, 0, 0, time.Minute)
+
+	if err := m.RunOnce(context.Background()); err != nil {
+		t.Fatalf("RunOnce error: %v", err)
+	}
+
+	member, err := store.Get(context.Background(), repository.AlarmNoSpace)
+	if err != nil {
+		t.Fatalf("Get error: %v", err)
+	}
+	if member != nil {
+		t.Error("expected NOSPACE alarm to be cleared")
+	}
+}
+
+func TestMonitorRunOnceActivatesDegradedOverErrorRateThreshold(t *testing.T) {
+	store := newFakeAlarmStore()
+	probe := &fakeProbe{errorRate: 0.9}
+	m := New(store, probe, probe, 0, 0, 0.5, time.Minute)
+
+	if err := m.RunOnce(context.Background()); err != nil {
+		t.Fatalf("RunOnce error: %v", err)
+	}
+
+	member, err := store.Get(context.Background(), repository.AlarmDegraded)
+	if err != nil {
+		t.Fatalf("Get error: %v", err)
+	}
+	if member == nil {
+		t.Fatal("expected DEGRADED alarm to be active")
+	}
+}
@@ -0,0 +1,94 @@
+// Package decay periodically rescores EpisodicMemory.AccessibilityScore,
+// purging memories that fall below a minimum score.
+package decay
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/lexlapax/cogmem/internal/domain/entity"
+	"github.com/lexlapax/cogmem/internal/domain/repository"
+	"github.com/lexlapax/cogmem/internal/infrastructure/log"
+)
+
+// Decayer runs on Interval ticks, updating every stored EpisodicMemory's
+// AccessibilityScore via:
+//
+//	score_new = score_old * exp(-BaseRate * hours_since_last_access) + ValenceWeight * valence
+//
+// Memories whose rescored AccessibilityScore falls below MinScore are deleted.
+type Decayer struct {
+	repo          repository.EpisodicRepository
+	baseRate      float64
+	valenceWeight float64
+	minScore      float64
+	interval      time.Duration
+	batchSize     int
+	logger        *log.Logger
+}
+
+// New constructs a Decayer. batchSize controls how many memories are loaded per
+// repository.EpisodicRepository.IteratePartitions batch.
+func New(repo repository.EpisodicRepository, baseRate, valenceWeight, minScore float64, interval time.Duration, batchSize int) *Decayer {
+	return &Decayer{
+		repo:          repo,
+		baseRate:      baseRate,
+		valenceWeight: valenceWeight,
+		minScore:      minScore,
+		interval:      interval,
+		batchSize:     batchSize,
+		logger:        log.Default(),
+	}
+}
+
+// RunOnce applies a single decay pass across every stored memory. Exposed
+// separately from Start for tests and one-shot invocations.
+func (d *Decayer) RunOnce(ctx context.Context) error {
+	now := time.Now()
+	return d.repo.IteratePartitions(ctx, d.batchSize, func(ctx context.Context, pCtx entity.PartitionContext, batch []*entity.EpisodicMemory) error {
+		for _, mem := range batch {
+			score := d.decayedScore(mem, now)
+			if score < d.minScore {
+				if err := d.repo.Delete(ctx, mem.ID, pCtx); err != nil {
+					return fmt.Errorf("purge memory %s: %w", mem.ID, err)
+				}
+				continue
+			}
+			if err := d.repo.UpdateAccessibility(ctx, mem.ID, score, pCtx); err != nil {
+				return fmt.Errorf("update accessibility for %s: %w", mem.ID, err)
+			}
+		}
+		return nil
+	})
+}
+
+// Start runs decay passes on every Interval tick until ctx is canceled.
+func (d *Decayer) Start(ctx context.Context) error {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if err := d.RunOnce(ctx); err != nil {
+				d.logger.Error("decay pass failed", "error", err)
+			}
+		}
+	}
+}
+
+// decayedScore computes mem's rescored AccessibilityScore as of now.
+func (d *Decayer) decayedScore(mem *entity.EpisodicMemory, now time.Time) float64 {
+	hours := now.Sub(mem.LastAccessed).Hours()
+	if hours < 0 {
+		hours = 0
+	}
+	var valence float64
+	if mem.Valence != nil {
+		valence = *mem.Valence
+	}
+	return mem.AccessibilityScore*math.Exp(-d.baseRate*hours) + d.valenceWeight*valence
+}
@@ -0,0 +1,114 @@
+package decay
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/lexlapax/cogmem/internal/domain/entity"
+	"github.com/lexlapax/cogmem/internal/domain/repository"
+)
+
+// fakeRepository is a minimal in-memory repository.EpisodicRepository used to
+// exercise Decayer without a real storage backend.
+type fakeRepository struct {
+	mems map[uuid.UUID]*entity.EpisodicMemory
+}
+
+func newFakeRepository(mems ...*entity.EpisodicMemory) *fakeRepository {
+	r := &fakeRepository{mems: map[uuid.UUID]*entity.EpisodicMemory{}}
+	for _, m := range mems {
+		r.mems[m.ID] = m
+	}
+	return r
+}
+
+func (r *fakeRepository) Save(ctx context.Context, mem *entity.EpisodicMemory) error {
+	r.mems[mem.ID] = mem
+	return nil
+}
+
+func (r *fakeRepository) FindByID(ctx context.Context, id uuid.UUID, pCtx entity.PartitionContext) (*entity.EpisodicMemory, error) {
+	mem, ok := r.mems[id]
+	if !ok {
+		return nil, nil
+	}
+	return mem, nil
+}
+
+func (r *fakeRepository) FindByVector(ctx context.Context, vector []float32, limit int, pCtx entity.PartitionContext) ([]*entity.EpisodicMemory, error) {
+	return nil, nil
+}
+
+func (r *fakeRepository) FindRecent(ctx context.Context, limit int, pCtx entity.PartitionContext) ([]*entity.EpisodicMemory, error) {
+	return nil, nil
+}
+
+func (r *fakeRepository) UpdateAccessibility(ctx context.Context, id uuid.UUID, score float64, pCtx entity.PartitionContext) error {
+	mem, ok := r.mems[id]
+	if !ok {
+		return nil
+	}
+	mem.AccessibilityScore = score
+	return nil
+}
+
+func (r *fakeRepository) Delete(ctx context.Context, id uuid.UUID, pCtx entity.PartitionContext) error {
+	delete(r.mems, id)
+	return nil
+}
+
+func (r *fakeRepository) IteratePartitions(ctx context.Context, batchSize int, fn repository.PartitionBatchFunc) error {
+	byUser := map[uuid.UUID][]*entity.EpisodicMemory{}
+	for _, mem := range r.mems {
+		byUser[mem.UserID] = append(byUser[mem.UserID], mem)
+	}
+	for userID, mems := range byUser {
+		if err := fn(ctx, entity.PartitionContext{UserID: userID}, mems); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var _ repository.EpisodicRepository = (*fakeRepository)(nil)
+
+func TestDecayerRunOnceRescoresAccessibility(t *testing.T) {
+	userID := uuid.New()
+	mem := entity.NewEpisodicMemory(userID, nil, "content", []float32{0.1}, time.Now().UTC(), "user")
+	mem.LastAccessed = time.Now().UTC().Add(-10 * time.Hour)
+	repo := newFakeRepository(mem)
+
+	d := New(repo, 0.1, 0, -1, time.Hour, 10)
+	if err := d.RunOnce(context.Background()); err != nil {
+		t.Fatalf("RunOnce error: %v", err)
+	}
+
+	got := repo.mems[mem.ID]
+	if got == nil {
+		t.Fatal("expected memory to remain, got nil")
+	}
+	wantScore := 1.0 * math.Exp(-0.1*10)
+	if got.AccessibilityScore < wantScore-1e-9 || got.AccessibilityScore > wantScore+1e-9 {
+		t.Errorf("AccessibilityScore = %v; want %v", got.AccessibilityScore, wantScore)
+	}
+}
+
+func TestDecayerRunOncePurgesBelowMinScore(t *testing.T) {
+	userID := uuid.New()
+	mem := entity.NewEpisodicMemory(userID, nil, "content", []float32{0.1}, time.Now().UTC(), "user")
+	mem.LastAccessed = time.Now().UTC().Add(-1000 * time.Hour)
+	repo := newFakeRepository(mem)
+
+	d := New(repo, 1.0, 0, 0.5, time.Hour, 10)
+	if err := d.RunOnce(context.Background()); err != nil {
+		t.Fatalf("RunOnce error: %v", err)
+	}
+
+	if _, ok := repo.mems[mem.ID]; ok {
+		t.Error("expected memory to be purged")
+	}
+}
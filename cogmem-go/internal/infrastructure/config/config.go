@@ -16,9 +16,65 @@ type Config struct {
 	DecayBaseRate      float64       `mapstructure:"decay_base_rate"`
 	DecayValenceWeight float64       `mapstructure:"decay_valence_weight"`
 	DecayInterval      time.Duration `mapstructure:"decay_interval"`
+	// DecayMinScore is the purge threshold: memories whose AccessibilityScore
+	// falls below this value are deleted by the decay subsystem.
+	DecayMinScore float64 `mapstructure:"decay_min_score"`
+	// DecayAccessibilityWeight controls how strongly AccessibilityScore boosts
+	// FindByVector/FindRecent ordering alongside vector distance/recency.
+	DecayAccessibilityWeight float64 `mapstructure:"decay_accessibility_weight"`
+	// DecayBatchSize controls how many memories the decay subsystem rescoring
+	// pass loads per IteratePartitions batch.
+	DecayBatchSize int           `mapstructure:"decay_batch_size"`
+	Storage        StorageConfig `mapstructure:"storage"`
+	// LogSamplingRate is the fraction (0..1) of successful-call debug events
+	// emitted by a log.MetaLogger; failures are always logged regardless.
+	LogSamplingRate float64     `mapstructure:"log_sampling_rate"`
+	Admin           AdminConfig `mapstructure:"admin"`
+	Alarm           AlarmConfig `mapstructure:"alarm"`
 	// Future Lua sandbox settings can be added here
 }
 
+// AlarmConfig configures the health alarm monitor.
+type AlarmConfig struct {
+	// MaxRows is the episodic_memory row count above which a NOSPACE alarm is
+	// raised; zero disables the check.
+	MaxRows int64 `mapstructure:"max_rows"`
+	// MaxDatabaseBytes is the pg_database_size threshold above which a NOSPACE
+	// alarm is raised; zero disables the check.
+	MaxDatabaseBytes int64 `mapstructure:"max_database_bytes"`
+	// ErrorRateThreshold is the fraction (0..1) of failed repository calls
+	// above which a DEGRADED alarm is raised; zero disables the check.
+	ErrorRateThreshold float64 `mapstructure:"error_rate_threshold"`
+	// Interval is how often the alarm monitor samples capacity and error rate.
+	Interval time.Duration `mapstructure:"interval"`
+}
+
+// AdminConfig configures the admin HTTP API.
+type AdminConfig struct {
+	// ListenAddr is the address the admin server listens on, e.g. ":8090".
+	ListenAddr string `mapstructure:"listen_addr"`
+	// Enabled controls whether the admin server is started.
+	Enabled bool `mapstructure:"enabled"`
+	// AuthToken, if set, must be presented as a Bearer token on every /memories
+	// request; /health and /metrics do not check it.
+	AuthToken string `mapstructure:"auth_token"`
+}
+
+// StorageConfig selects and configures the EpisodicRepository backend.
+type StorageConfig struct {
+	// Backend selects the repository implementation: "postgres" (default) or "disk".
+	Backend string            `mapstructure:"backend"`
+	Disk    DiskStorageConfig `mapstructure:"disk"`
+}
+
+// DiskStorageConfig configures the embedded BadgerDB-backed repository.
+type DiskStorageConfig struct {
+	// Directory is the path to the BadgerDB data directory.
+	Directory string `mapstructure:"directory"`
+	// AutoCreate creates Directory if it does not already exist.
+	AutoCreate bool `mapstructure:"auto_create"`
+}
+
 // LoadConfig reads configuration from config.yaml, .env, and environment variables.
 // Precedence: config.yaml -> .env file -> environment variables.
 func LoadConfig() (*Config, error) {
@@ -43,6 +99,15 @@ func LoadConfig() (*Config, error) {
 		}
 	}
 
+	// Defaults for optional settings
+	v.SetDefault("storage.backend", "postgres")
+	v.SetDefault("log_sampling_rate", 1.0)
+	v.SetDefault("decay_batch_size", 100)
+	v.SetDefault("admin.listen_addr", ":8090")
+	v.SetDefault("alarm.max_rows", 1_000_000)
+	v.SetDefault("alarm.error_rate_threshold", 0.5)
+	v.SetDefault("alarm.interval", time.Minute)
+
 	// Allow environment variable overrides (e.g., DATABASE_URL)
 	v.AutomaticEnv()
 	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_", "-", "_"))
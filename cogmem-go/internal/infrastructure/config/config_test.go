@@ -21,7 +21,9 @@ func TestLoadConfigFile(t *testing.T) {
 embedding_dim: 42
 decay_base_rate: 0.12
 decay_valence_weight: 0.34
-decay_interval: "2h"`
+decay_interval: "2h"
+decay_min_score: 0.05
+decay_accessibility_weight: 0.2`
 	if err := os.WriteFile("config.yaml", []byte(content), 0644); err != nil {
 		t.Fatalf("WriteFile failed: %v", err)
 	}
@@ -44,6 +46,15 @@ decay_interval: "2h"`
 	if cfg.DecayInterval != 2*time.Hour {
 		t.Errorf("DecayInterval = %v; want %v", cfg.DecayInterval, 2*time.Hour)
 	}
+	if cfg.DecayMinScore != 0.05 {
+		t.Errorf("DecayMinScore = %v; want %v", cfg.DecayMinScore, 0.05)
+	}
+	if cfg.DecayAccessibilityWeight != 0.2 {
+		t.Errorf("DecayAccessibilityWeight = %v; want %v", cfg.DecayAccessibilityWeight, 0.2)
+	}
+	if cfg.DecayBatchSize != 100 {
+		t.Errorf("DecayBatchSize = %d; want default 100", cfg.DecayBatchSize)
+	}
 }
 
 func TestLoadConfigEnvOverride(t *testing.T) {
@@ -77,6 +88,113 @@ decay_interval: "1h"`
 	}
 }
 
+// TestLoadConfigStorageBackend verifies storage backend settings and their default.
+func TestLoadConfigStorageBackend(t *testing.T) {
+	dir := t.TempDir()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Getwd failed: %v", err)
+	}
+	defer os.Chdir(wd)
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("Chdir failed: %v", err)
+	}
+	content := `database_url: "x"
+embedding_dim: 1
+decay_base_rate: 0.1
+decay_valence_weight: 0.2
+decay_interval: "1h"
+storage:
+  backend: "disk"
+  disk:
+    directory: "/tmp/cogmem-data"
+    auto_create: true`
+	if err := os.WriteFile("config.yaml", []byte(content), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig error: %v", err)
+	}
+	if cfg.Storage.Backend != "disk" {
+		t.Errorf("Storage.Backend = %q; want %q", cfg.Storage.Backend, "disk")
+	}
+	if cfg.Storage.Disk.Directory != "/tmp/cogmem-data" {
+		t.Errorf("Storage.Disk.Directory = %q; want %q", cfg.Storage.Disk.Directory, "/tmp/cogmem-data")
+	}
+	if !cfg.Storage.Disk.AutoCreate {
+		t.Error("Storage.Disk.AutoCreate = false; want true")
+	}
+}
+
+// TestLoadConfigStorageBackendDefault verifies the default backend is "postgres".
+func TestLoadConfigStorageBackendDefault(t *testing.T) {
+	dir := t.TempDir()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Getwd failed: %v", err)
+	}
+	defer os.Chdir(wd)
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("Chdir failed: %v", err)
+	}
+	content := `database_url: "x"
+embedding_dim: 1
+decay_base_rate: 0.1
+decay_valence_weight: 0.2
+decay_interval: "1h"`
+	if err := os.WriteFile("config.yaml", []byte(content), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig error: %v", err)
+	}
+	if cfg.Storage.Backend != "postgres" {
+		t.Errorf("Storage.Backend = %q; want %q", cfg.Storage.Backend, "postgres")
+	}
+}
+
+// TestLoadConfigAlarm verifies alarm settings and their defaults.
+func TestLoadConfigAlarm(t *testing.T) {
+	dir := t.TempDir()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Getwd failed: %v", err)
+	}
+	defer os.Chdir(wd)
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("Chdir failed: %v", err)
+	}
+	content := `database_url: "x"
+embedding_dim: 1
+decay_base_rate: 0.1
+decay_valence_weight: 0.2
+decay_interval: "1h"
+alarm:
+  max_rows: 500
+  max_database_bytes: 1073741824`
+	if err := os.WriteFile("config.yaml", []byte(content), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig error: %v", err)
+	}
+	if cfg.Alarm.MaxRows != 500 {
+		t.Errorf("Alarm.MaxRows = %d; want %d", cfg.Alarm.MaxRows, 500)
+	}
+	if cfg.Alarm.MaxDatabaseBytes != 1073741824 {
+		t.Errorf("Alarm.MaxDatabaseBytes = %d; want %d", cfg.Alarm.MaxDatabaseBytes, 1073741824)
+	}
+	if cfg.Alarm.ErrorRateThreshold != 0.5 {
+		t.Errorf("Alarm.ErrorRateThreshold = %v; want default %v", cfg.Alarm.ErrorRateThreshold, 0.5)
+	}
+	if cfg.Alarm.Interval != time.Minute {
+		t.Errorf("Alarm.Interval = %v; want default %v", cfg.Alarm.Interval, time.Minute)
+	}
+}
+
 // TestLoadConfigDotEnv verifies that values from a .env file are loaded.
 func TestLoadConfigDotEnv(t *testing.T) {
 	dir := t.TempDir()
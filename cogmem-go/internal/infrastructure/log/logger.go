@@ -0,0 +1,141 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"time"
+
+	"github.com/lexlapax/cogmem/internal/domain/entity"
+)
+
+// ctxKey namespaces values stored in a context.Context by this package.
+type ctxKey int
+
+const (
+	ctxKeyTraceID ctxKey = iota
+	ctxKeyPartition
+)
+
+// ContextWithTraceID returns a context carrying traceID, retrievable by
+// Logger.WithContext.
+func ContextWithTraceID(ctx context.Context, traceID string) context.Context {
+	return context.WithValue(ctx, ctxKeyTraceID, traceID)
+}
+
+// ContextWithPartition returns a context carrying pCtx, retrievable by
+// Logger.WithContext.
+func ContextWithPartition(ctx context.Context, pCtx entity.PartitionContext) context.Context {
+	return context.WithValue(ctx, ctxKeyPartition, pCtx)
+}
+
+// Logger wraps slog.Logger, adding accumulation of structured attributes via
+// With and extraction of request-scoped fields via WithContext.
+type Logger struct {
+	slog *slog.Logger
+}
+
+// NewLogger wraps an existing slog.Logger.
+func NewLogger(s *slog.Logger) *Logger {
+	return &Logger{slog: s}
+}
+
+// Default returns a Logger wrapping the current slog default logger.
+func Default() *Logger {
+	return &Logger{slog: slog.Default()}
+}
+
+// With returns a Logger with additional structured attributes appended,
+// alternating key/value pairs the same as slog.Logger.With.
+func (l *Logger) With(args ...any) *Logger {
+	return &Logger{slog: l.slog.With(args...)}
+}
+
+// WithContext returns a Logger annotated with request-scoped fields found in
+// ctx: a trace ID set via ContextWithTraceID, and the UserID/EntityID of a
+// PartitionContext set via ContextWithPartition. Fields absent from ctx are
+// omitted.
+func (l *Logger) WithContext(ctx context.Context) *Logger {
+	logger := l
+	if traceID, ok := ctx.Value(ctxKeyTraceID).(string); ok {
+		logger = logger.With("trace_id", traceID)
+	}
+	if pCtx, ok := ctx.Value(ctxKeyPartition).(entity.PartitionContext); ok {
+		logger = logger.With("user_id", pCtx.UserID)
+		if pCtx.EntityID != nil {
+			logger = logger.With("entity_id", *pCtx.EntityID)
+		}
+	}
+	return logger
+}
+
+// Debug logs msg at debug level with the accumulated attributes.
+func (l *Logger) Debug(msg string, args ...any) { l.slog.Debug(msg, args...) }
+
+// Info logs msg at info level with the accumulated attributes.
+func (l *Logger) Info(msg string, args ...any) { l.slog.Info(msg, args...) }
+
+// Warn logs msg at warn level with the accumulated attributes.
+func (l *Logger) Warn(msg string, args ...any) { l.slog.Warn(msg, args...) }
+
+// Error logs msg at error level with the accumulated attributes.
+func (l *Logger) Error(msg string, args ...any) { l.slog.Error(msg, args...) }
+
+// MetaLogger stamps every repository call with a consistent set of
+// operational fields (op, partition, latency_ms, rowcount, error class),
+// sampling successful calls to avoid overwhelming output on hot paths such as
+// vector search.
+type MetaLogger struct {
+	logger       *Logger
+	samplingRate float64
+}
+
+// NewMetaLogger constructs a MetaLogger. samplingRate is the fraction (0..1)
+// of successful-call debug events that are emitted; failures are always
+// logged regardless of sampling.
+func NewMetaLogger(logger *Logger, samplingRate float64) *MetaLogger {
+	return &MetaLogger{logger: logger, samplingRate: samplingRate}
+}
+
+// Call runs fn, timing it and logging one structured event tagged with op,
+// partition, latency_ms, and rowcount: a sampled debug event on success, or an
+// error event carrying an error_class on failure.
+func (m *MetaLogger) Call(ctx context.Context, op string, pCtx entity.PartitionContext, fn func() (rowcount int, err error)) error {
+	start := time.Now()
+	rowcount, err := fn()
+	latency := time.Since(start)
+
+	logger := m.logger.WithContext(ContextWithPartition(ctx, pCtx)).With(
+		"op", op,
+		"latency_ms", latency.Milliseconds(),
+		"rowcount", rowcount,
+	)
+	if err != nil {
+		logger.With("error_class", errorClass(err)).Error(fmt.Sprintf("%s failed", op))
+		return err
+	}
+	if m.shouldSample() {
+		logger.Debug(fmt.Sprintf("%s succeeded", op))
+	}
+	return nil
+}
+
+// shouldSample reports whether the next successful-call event should be
+// emitted, per samplingRate.
+func (m *MetaLogger) shouldSample() bool {
+	switch {
+	case m.samplingRate >= 1:
+		return true
+	case m.samplingRate <= 0:
+		return false
+	default:
+		return rand.Float64() < m.samplingRate
+	}
+}
+
+// errorClass returns a coarse classification of err for log filtering, based
+// on its concrete Go type.
+func errorClass(err error) string {
+	return fmt.Sprintf("%T", err)
+}
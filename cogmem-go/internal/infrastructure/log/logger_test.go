@@ -0,0 +1,81 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/lexlapax/cogmem/internal/domain/entity"
+)
+
+func TestLoggerWithContext(t *testing.T) {
+	var buf bytes.Buffer
+	handler := slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})
+	logger := NewLogger(slog.New(handler))
+
+	userID := uuid.New()
+	ctx := ContextWithTraceID(context.Background(), "trace-123")
+	ctx = ContextWithPartition(ctx, entity.PartitionContext{UserID: userID})
+
+	logger.WithContext(ctx).Info("hello")
+	out := buf.String()
+	if !strings.Contains(out, "trace_id=trace-123") {
+		t.Errorf("expected trace_id in log output, got %q", out)
+	}
+	if !strings.Contains(out, userID.String()) {
+		t.Errorf("expected user_id in log output, got %q", out)
+	}
+}
+
+func TestMetaLoggerCallSuccessAndFailure(t *testing.T) {
+	var buf bytes.Buffer
+	handler := slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})
+	meta := NewMetaLogger(NewLogger(slog.New(handler)), 1.0)
+	pCtx := entity.PartitionContext{UserID: uuid.New()}
+
+	err := meta.Call(context.Background(), "Save", pCtx, func() (int, error) {
+		return 1, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "op=Save") || !strings.Contains(out, "rowcount=1") {
+		t.Errorf("expected op/rowcount in success log, got %q", out)
+	}
+
+	buf.Reset()
+	wantErr := errors.New("boom")
+	err = meta.Call(context.Background(), "Save", pCtx, func() (int, error) {
+		return 0, wantErr
+	})
+	if err != wantErr {
+		t.Fatalf("expected wantErr, got %v", err)
+	}
+	out = buf.String()
+	if !strings.Contains(out, "error_class") {
+		t.Errorf("expected error_class in failure log, got %q", out)
+	}
+}
+
+func TestMetaLoggerSamplingSuppressesSuccess(t *testing.T) {
+	var buf bytes.Buffer
+	handler := slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})
+	meta := NewMetaLogger(NewLogger(slog.New(handler)), 0.0)
+	pCtx := entity.PartitionContext{UserID: uuid.New()}
+
+	err := meta.Call(context.Background(), "FindByVector", pCtx, func() (int, error) {
+		return 5, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected no output with sampling rate 0, got %q", buf.String())
+	}
+}
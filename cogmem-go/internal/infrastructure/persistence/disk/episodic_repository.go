@@ -0,0 +1,485 @@
+// Package disk implements repository.EpisodicRepository on top of an embedded
+// BadgerDB key-value store, for deployments that should not require a Postgres
+// instance (edge/embedded LLM agents).
+package disk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	badger "github.com/dgraph-io/badger/v4"
+	"github.com/google/uuid"
+
+	"github.com/lexlapax/cogmem/internal/domain/entity"
+	"github.com/lexlapax/cogmem/internal/domain/repository"
+)
+
+// EpisodicRepository implements repository.EpisodicRepository using an embedded
+// BadgerDB store. Records are JSON-encoded and keyed by a partition prefix derived
+// from PartitionContext.UserID, with a secondary time-ordered index maintained
+// alongside each record. FindRecent walks that index when accessibility boosting
+// is disabled; FindRecent under boosting and FindByVector always fall back to a
+// brute-force partition scan.
+type EpisodicRepository struct {
+	db *badger.DB
+
+	// mu matches the concurrency semantics of the Postgres backend: a single
+	// writer, many readers, with writes blocking until in-flight reads complete.
+	mu sync.RWMutex
+
+	// accessibilityWeight controls how strongly AccessibilityScore boosts
+	// FindByVector/FindRecent ordering.
+	accessibilityWeight float64
+}
+
+// NewEpisodicRepository opens (creating if necessary and autoCreate is set) a
+// BadgerDB store rooted at dir. accessibilityWeight controls how strongly
+// AccessibilityScore boosts FindByVector/FindRecent ordering.
+func NewEpisodicRepository(dir string, autoCreate bool, accessibilityWeight float64) (*EpisodicRepository, error) {
+	if autoCreate {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("create storage directory %q: %w", dir, err)
+		}
+	}
+	opts := badger.DefaultOptions(dir).WithLogger(nil)
+	db, err := badger.Open(opts)
+	if err != nil {
+		return nil, fmt.Errorf("open badger db at %q: %w", dir, err)
+	}
+	return &EpisodicRepository{db: db, accessibilityWeight: accessibilityWeight}, nil
+}
+
+// Close releases the underlying BadgerDB handle.
+func (r *EpisodicRepository) Close() error {
+	return r.db.Close()
+}
+
+// memKey is the primary record key for a memory within its user partition.
+func memKey(userID uuid.UUID, id uuid.UUID) []byte {
+	return []byte(fmt.Sprintf("mem/%s/%s", userID, id))
+}
+
+// indexKey is the secondary time-ordered index key used to look up a memory's
+// timestamp-sorted position without decoding every record.
+func indexKey(userID uuid.UUID, ts int64, id uuid.UUID) []byte {
+	return []byte(fmt.Sprintf("idx/%s/%020d/%s", userID, ts, id))
+}
+
+// memPrefix scopes an iteration to a user partition's primary records.
+func memPrefix(userID uuid.UUID) []byte {
+	return []byte(fmt.Sprintf("mem/%s/", userID))
+}
+
+// indexPrefix scopes an iteration to a user partition's time index entries.
+func indexPrefix(userID uuid.UUID) []byte {
+	return []byte(fmt.Sprintf("idx/%s/", userID))
+}
+
+// matchesPartition reports whether mem is visible under pCtx, mirroring the
+// Postgres backend's "entity_id IS NULL OR entity_id = $n" filter.
+func matchesPartition(mem *entity.EpisodicMemory, pCtx entity.PartitionContext) bool {
+	if mem.EntityID == nil {
+		return true
+	}
+	return pCtx.EntityID != nil && *mem.EntityID == *pCtx.EntityID
+}
+
+// Save persists a new EpisodicMemory record and its time index entry.
+func (r *EpisodicRepository) Save(ctx context.Context, mem *entity.EpisodicMemory) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data, err := json.Marshal(mem)
+	if err != nil {
+		return fmt.Errorf("marshal episodic memory: %w", err)
+	}
+	return r.db.Update(func(txn *badger.Txn) error {
+		if err := txn.Set(memKey(mem.UserID, mem.ID), data); err != nil {
+			return fmt.Errorf("set episodic memory: %w", err)
+		}
+		if err := txn.Set(indexKey(mem.UserID, mem.Timestamp.UnixNano(), mem.ID), []byte(mem.ID.String())); err != nil {
+			return fmt.Errorf("set time index: %w", err)
+		}
+		return nil
+	})
+}
+
+// FindByID retrieves a memory by ID within the given partition.
+func (r *EpisodicRepository) FindByID(ctx context.Context, id uuid.UUID, pCtx entity.PartitionContext) (*entity.EpisodicMemory, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var mem entity.EpisodicMemory
+	err := r.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get(memKey(pCtx.UserID, id))
+		if err != nil {
+			return err
+		}
+		return item.Value(func(val []byte) error {
+			return json.Unmarshal(val, &mem)
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !matchesPartition(&mem, pCtx) {
+		return nil, badger.ErrKeyNotFound
+	}
+	return &mem, nil
+}
+
+// FindRecent returns the most recent memories in the partition, up to limit,
+// boosted by AccessibilityScore according to the repository's configured
+// accessibility weight. When accessibilityWeight is zero (the default), it walks
+// the secondary time index newest-first and stops at limit instead of scanning
+// and decoding every record in the partition; boosting requires each record's
+// AccessibilityScore, which the index does not carry, so a non-zero weight falls
+// back to a full scan.
+func (r *EpisodicRepository) FindRecent(ctx context.Context, limit int, pCtx entity.PartitionContext) ([]*entity.EpisodicMemory, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.accessibilityWeight == 0 {
+		return r.findRecentByIndex(pCtx, limit)
+	}
+	return r.findRecentByScan(pCtx, limit)
+}
+
+// findRecentByIndex returns up to limit memories in pCtx's partition, newest
+// first, by walking the time index in reverse and loading only the records it
+// visits. Must be called with r.mu held.
+func (r *EpisodicRepository) findRecentByIndex(pCtx entity.PartitionContext, limit int) ([]*entity.EpisodicMemory, error) {
+	var results []*entity.EpisodicMemory
+	err := r.db.View(func(txn *badger.Txn) error {
+		prefix := indexPrefix(pCtx.UserID)
+		opts := badger.DefaultIteratorOptions
+		opts.Reverse = true
+		it := txn.NewIterator(opts)
+		defer it.Close()
+		upperBound := append(append([]byte{}, prefix...), 0xFF)
+		for it.Seek(upperBound); it.ValidForPrefix(prefix) && len(results) < limit; it.Next() {
+			var idStr string
+			if err := it.Item().Value(func(val []byte) error {
+				idStr = string(val)
+				return nil
+			}); err != nil {
+				return fmt.Errorf("read time index entry: %w", err)
+			}
+			id, err := uuid.Parse(idStr)
+			if err != nil {
+				return fmt.Errorf("parse time index entry: %w", err)
+			}
+			mem, err := getMem(txn, pCtx.UserID, id)
+			if err != nil {
+				if err == badger.ErrKeyNotFound {
+					continue
+				}
+				return fmt.Errorf("load indexed memory: %w", err)
+			}
+			if !matchesPartition(mem, pCtx) {
+				continue
+			}
+			results = append(results, mem)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("find recent by index: %w", err)
+	}
+	return results, nil
+}
+
+// findRecentByScan returns up to limit memories in pCtx's partition ranked by
+// timestamp boosted by AccessibilityScore, via a full partition scan. The recency
+// term is expressed in hours relative to now rather than a raw Unix timestamp, so
+// accessibilityWeight reads as "how many hours of extra age a fully-accessible
+// memory can overcome" and stays comparable to the weight values sane for
+// FindByVector's [0,2] cosine distance. Must be called with r.mu held.
+func (r *EpisodicRepository) findRecentByScan(pCtx entity.PartitionContext, limit int) ([]*entity.EpisodicMemory, error) {
+	type scored struct {
+		mem   *entity.EpisodicMemory
+		score float64
+	}
+	now := time.Now()
+	var candidates []scored
+	err := r.db.View(func(txn *badger.Txn) error {
+		prefix := memPrefix(pCtx.UserID)
+		it := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer it.Close()
+		for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+			var mem entity.EpisodicMemory
+			if err := it.Item().Value(func(val []byte) error {
+				return json.Unmarshal(val, &mem)
+			}); err != nil {
+				return fmt.Errorf("unmarshal scanned memory: %w", err)
+			}
+			if !matchesPartition(&mem, pCtx) {
+				continue
+			}
+			score := mem.Timestamp.Sub(now).Hours() + r.accessibilityWeight*mem.AccessibilityScore
+			candidates = append(candidates, scored{mem: &mem, score: score})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("find recent: %w", err)
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].score > candidates[j].score })
+	if limit < len(candidates) {
+		candidates = candidates[:limit]
+	}
+	results := make([]*entity.EpisodicMemory, len(candidates))
+	for i, c := range candidates {
+		results[i] = c.mem
+	}
+	return results, nil
+}
+
+// FindByVector performs a brute-force cosine-distance scan over the partition's
+// vectors, boosted by AccessibilityScore according to the repository's
+// configured accessibility weight. Acceptable for the small deployments this
+// backend targets.
+func (r *EpisodicRepository) FindByVector(ctx context.Context, vector []float32, limit int, pCtx entity.PartitionContext) ([]*entity.EpisodicMemory, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	type scored struct {
+		mem   *entity.EpisodicMemory
+		score float64
+	}
+	var candidates []scored
+	err := r.db.View(func(txn *badger.Txn) error {
+		prefix := memPrefix(pCtx.UserID)
+		it := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer it.Close()
+		for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+			var mem entity.EpisodicMemory
+			if err := it.Item().Value(func(val []byte) error {
+				return json.Unmarshal(val, &mem)
+			}); err != nil {
+				return fmt.Errorf("unmarshal scanned memory: %w", err)
+			}
+			if !matchesPartition(&mem, pCtx) {
+				continue
+			}
+			distance := cosineDistance(vector, mem.Embedding)
+			candidates = append(candidates, scored{mem: &mem, score: distance - r.accessibilityWeight*mem.AccessibilityScore})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("vector scan: %w", err)
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].score < candidates[j].score })
+	if limit < len(candidates) {
+		candidates = candidates[:limit]
+	}
+	results := make([]*entity.EpisodicMemory, len(candidates))
+	for i, c := range candidates {
+		results[i] = c.mem
+	}
+	return results, nil
+}
+
+// UpdateAccessibility updates a memory's AccessibilityScore within the given partition.
+func (r *EpisodicRepository) UpdateAccessibility(ctx context.Context, id uuid.UUID, score float64, pCtx entity.PartitionContext) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.db.Update(func(txn *badger.Txn) error {
+		mem, err := getMem(txn, pCtx.UserID, id)
+		if err != nil {
+			return err
+		}
+		if !matchesPartition(mem, pCtx) {
+			return badger.ErrKeyNotFound
+		}
+		mem.AccessibilityScore = score
+		data, err := json.Marshal(mem)
+		if err != nil {
+			return fmt.Errorf("marshal episodic memory: %w", err)
+		}
+		if err := txn.Set(memKey(pCtx.UserID, id), data); err != nil {
+			return fmt.Errorf("set episodic memory: %w", err)
+		}
+		return nil
+	})
+}
+
+// Delete removes a memory and its time index entry within the given partition.
+func (r *EpisodicRepository) Delete(ctx context.Context, id uuid.UUID, pCtx entity.PartitionContext) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.db.Update(func(txn *badger.Txn) error {
+		mem, err := getMem(txn, pCtx.UserID, id)
+		if err != nil {
+			if err == badger.ErrKeyNotFound {
+				return nil
+			}
+			return err
+		}
+		if !matchesPartition(mem, pCtx) {
+			return nil
+		}
+		if err := txn.Delete(memKey(pCtx.UserID, id)); err != nil {
+			return fmt.Errorf("delete episodic memory: %w", err)
+		}
+		if err := txn.Delete(indexKey(pCtx.UserID, mem.Timestamp.UnixNano(), id)); err != nil {
+			return fmt.Errorf("delete time index: %w", err)
+		}
+		return nil
+	})
+}
+
+// IteratePartitions walks every user partition's memories in batches of
+// batchSize, invoking fn once per batch. Used by the decay subsystem to
+// rescore AccessibilityScore without loading the entire store into memory at
+// once: each batch is paged straight off the BadgerDB iterator, so a
+// partition larger than batchSize is never fully materialized.
+func (r *EpisodicRepository) IteratePartitions(ctx context.Context, batchSize int, fn repository.PartitionBatchFunc) error {
+	userIDs, err := r.listUserIDs()
+	if err != nil {
+		return fmt.Errorf("list partitions: %w", err)
+	}
+	for _, userID := range userIDs {
+		pCtx := entity.PartitionContext{UserID: userID}
+		afterID := uuid.Nil
+		for {
+			batch, err := r.findBatch(userID, batchSize, afterID)
+			if err != nil {
+				return fmt.Errorf("find batch for partition %s: %w", userID, err)
+			}
+			if len(batch) == 0 {
+				break
+			}
+			if err := fn(ctx, pCtx, batch); err != nil {
+				return fmt.Errorf("process batch for partition %s: %w", userID, err)
+			}
+			if len(batch) < batchSize {
+				break
+			}
+			afterID = batch[len(batch)-1].ID
+		}
+	}
+	return nil
+}
+
+// findBatch fetches one page, in key order, of a user partition's memories
+// with a primary key greater than afterID's, seeking straight past everything
+// already returned rather than re-walking it. Using a keyset on the last ID
+// actually returned, rather than an in-memory offset, means fn deleting
+// already-visited rows (as decay.Decayer does) can't shift later rows out
+// from under the next page.
+func (r *EpisodicRepository) findBatch(userID uuid.UUID, limit int, afterID uuid.UUID) ([]*entity.EpisodicMemory, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	prefix := memPrefix(userID)
+	seekKey := prefix
+	if afterID != uuid.Nil {
+		// Append a byte after the last-seen key so Seek lands strictly past it:
+		// any real key sharing that prefix is lexically longer, hence greater.
+		seekKey = append(memKey(userID, afterID), 0x00)
+	}
+	var results []*entity.EpisodicMemory
+	err := r.db.View(func(txn *badger.Txn) error {
+		it := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer it.Close()
+		for it.Seek(seekKey); it.ValidForPrefix(prefix) && len(results) < limit; it.Next() {
+			var mem entity.EpisodicMemory
+			if err := it.Item().Value(func(val []byte) error {
+				return json.Unmarshal(val, &mem)
+			}); err != nil {
+				return fmt.Errorf("unmarshal memory: %w", err)
+			}
+			results = append(results, &mem)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// listUserIDs returns the distinct user partitions present in the store.
+func (r *EpisodicRepository) listUserIDs() ([]uuid.UUID, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	seen := map[uuid.UUID]bool{}
+	err := r.db.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.PrefetchValues = false
+		prefix := []byte("mem/")
+		it := txn.NewIterator(opts)
+		defer it.Close()
+		for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+			parts := strings.SplitN(string(it.Item().Key()), "/", 3)
+			if len(parts) < 2 {
+				continue
+			}
+			userID, err := uuid.Parse(parts[1])
+			if err != nil {
+				continue
+			}
+			seen[userID] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uuid.UUID, 0, len(seen))
+	for id := range seen {
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// getMem loads a single record by user partition and ID from within an open txn.
+func getMem(txn *badger.Txn, userID, id uuid.UUID) (*entity.EpisodicMemory, error) {
+	item, err := txn.Get(memKey(userID, id))
+	if err != nil {
+		return nil, err
+	}
+	var mem entity.EpisodicMemory
+	err = item.Value(func(val []byte) error {
+		return json.Unmarshal(val, &mem)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &mem, nil
+}
+
+// cosineDistance returns 1 - cosine similarity between a and b, so that smaller
+// values indicate closer vectors (consistent with the Postgres backend's
+// pgvector `<=>` cosine distance operator).
+func cosineDistance(a, b []float32) float64 {
+	var dot, normA, normB float64
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	for i := 0; i < n; i++ {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 1
+	}
+	return 1 - dot/(math.Sqrt(normA)*math.Sqrt(normB))
+}
+
+// Ensure EpisodicRepository satisfies the interface.
+var _ repository.EpisodicRepository = (*EpisodicRepository)(nil)
@@ -0,0 +1,240 @@
+package disk
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/lexlapax/cogmem/internal/domain/entity"
+)
+
+func TestEpisodicRepository_SaveFind(t *testing.T) {
+	ctx := context.Background()
+	repo, err := NewEpisodicRepository(t.TempDir(), true, 0)
+	if err != nil {
+		t.Fatalf("NewEpisodicRepository error: %v", err)
+	}
+	defer repo.Close()
+
+	userID := uuid.New()
+	pCtx := entity.PartitionContext{UserID: userID}
+	now := time.Now().UTC().Truncate(time.Second)
+
+	e1 := []float32{1, 0, 0}
+	mem1 := entity.NewEpisodicMemory(userID, nil, "first", e1, now, "user")
+	if err := repo.Save(ctx, mem1); err != nil {
+		t.Fatalf("save mem1: %v", err)
+	}
+
+	e2 := []float32{0, 1, 0}
+	mem2 := entity.NewEpisodicMemory(userID, nil, "second", e2, now.Add(time.Minute), "user")
+	if err := repo.Save(ctx, mem2); err != nil {
+		t.Fatalf("save mem2: %v", err)
+	}
+
+	got1, err := repo.FindByID(ctx, mem1.ID, pCtx)
+	if err != nil {
+		t.Fatalf("FindByID error: %v", err)
+	}
+	if got1.ID != mem1.ID || got1.Content != "first" {
+		t.Errorf("FindByID returned %+v, want %+v", got1, mem1)
+	}
+
+	recent, err := repo.FindRecent(ctx, 10, pCtx)
+	if err != nil {
+		t.Fatalf("FindRecent error: %v", err)
+	}
+	if len(recent) != 2 {
+		t.Fatalf("FindRecent length = %d; want 2", len(recent))
+	}
+	if recent[0].ID != mem2.ID {
+		t.Errorf("Most recent ID = %v; want %v", recent[0].ID, mem2.ID)
+	}
+	if recent[1].ID != mem1.ID {
+		t.Errorf("Second recent ID = %v; want %v", recent[1].ID, mem1.ID)
+	}
+
+	otherCtx := entity.PartitionContext{UserID: uuid.New()}
+	none, err := repo.FindRecent(ctx, 10, otherCtx)
+	if err != nil {
+		t.Fatalf("FindRecent otherCtx error: %v", err)
+	}
+	if len(none) != 0 {
+		t.Errorf("Expected no rows for other partition, got %d", len(none))
+	}
+
+	vecResults, err := repo.FindByVector(ctx, e1, 1, pCtx)
+	if err != nil {
+		t.Fatalf("FindByVector error: %v", err)
+	}
+	if len(vecResults) != 1 {
+		t.Fatalf("FindByVector length = %d; want 1", len(vecResults))
+	}
+	if vecResults[0].ID != mem1.ID {
+		t.Errorf("FindByVector returned ID %v; want %v", vecResults[0].ID, mem1.ID)
+	}
+}
+
+func TestEpisodicRepository_UpdateAccessibilityAndDelete(t *testing.T) {
+	ctx := context.Background()
+	repo, err := NewEpisodicRepository(t.TempDir(), true, 0)
+	if err != nil {
+		t.Fatalf("NewEpisodicRepository error: %v", err)
+	}
+	defer repo.Close()
+
+	userID := uuid.New()
+	pCtx := entity.PartitionContext{UserID: userID}
+	mem := entity.NewEpisodicMemory(userID, nil, "content", []float32{0.1}, time.Now().UTC(), "user")
+	if err := repo.Save(ctx, mem); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	if err := repo.UpdateAccessibility(ctx, mem.ID, 0.25, pCtx); err != nil {
+		t.Fatalf("UpdateAccessibility error: %v", err)
+	}
+	got, err := repo.FindByID(ctx, mem.ID, pCtx)
+	if err != nil {
+		t.Fatalf("FindByID error: %v", err)
+	}
+	if got.AccessibilityScore != 0.25 {
+		t.Errorf("AccessibilityScore = %v; want 0.25", got.AccessibilityScore)
+	}
+
+	if err := repo.Delete(ctx, mem.ID, pCtx); err != nil {
+		t.Fatalf("Delete error: %v", err)
+	}
+	if _, err := repo.FindByID(ctx, mem.ID, pCtx); err == nil {
+		t.Error("expected error finding deleted memory")
+	}
+}
+
+// TestEpisodicRepository_FindRecentAccessibilityBoost verifies that FindRecent's
+// full-scan fallback (used when accessibilityWeight is non-zero) can reorder an
+// older-but-more-accessible memory ahead of a newer one, which the index-only
+// fast path (accessibilityWeight == 0) has no way to do. It uses a weight (1.0)
+// and a gap (one minute) within the range the same config value is expected to
+// be sane for FindByVector's [0,2] cosine distance, since the recency term is
+// normalized to hours rather than a raw Unix timestamp.
+func TestEpisodicRepository_FindRecentAccessibilityBoost(t *testing.T) {
+	ctx := context.Background()
+	repo, err := NewEpisodicRepository(t.TempDir(), true, 1.0)
+	if err != nil {
+		t.Fatalf("NewEpisodicRepository error: %v", err)
+	}
+	defer repo.Close()
+
+	userID := uuid.New()
+	pCtx := entity.PartitionContext{UserID: userID}
+	now := time.Now().UTC().Truncate(time.Second)
+
+	older := entity.NewEpisodicMemory(userID, nil, "older", []float32{0.1}, now, "user")
+	if err := repo.Save(ctx, older); err != nil {
+		t.Fatalf("save older: %v", err)
+	}
+	newer := entity.NewEpisodicMemory(userID, nil, "newer", []float32{0.1}, now.Add(time.Minute), "user")
+	if err := repo.Save(ctx, newer); err != nil {
+		t.Fatalf("save newer: %v", err)
+	}
+
+	if err := repo.UpdateAccessibility(ctx, older.ID, 3.0, pCtx); err != nil {
+		t.Fatalf("UpdateAccessibility error: %v", err)
+	}
+
+	recent, err := repo.FindRecent(ctx, 10, pCtx)
+	if err != nil {
+		t.Fatalf("FindRecent error: %v", err)
+	}
+	if len(recent) != 2 {
+		t.Fatalf("FindRecent length = %d; want 2", len(recent))
+	}
+	if recent[0].ID != older.ID {
+		t.Errorf("Most recent ID = %v; want %v (boosted by accessibility)", recent[0].ID, older.ID)
+	}
+}
+
+func TestEpisodicRepository_IteratePartitions(t *testing.T) {
+	ctx := context.Background()
+	repo, err := NewEpisodicRepository(t.TempDir(), true, 0)
+	if err != nil {
+		t.Fatalf("NewEpisodicRepository error: %v", err)
+	}
+	defer repo.Close()
+
+	userA, userB := uuid.New(), uuid.New()
+	for _, uid := range []uuid.UUID{userA, userA, userB} {
+		mem := entity.NewEpisodicMemory(uid, nil, "x", []float32{0.1}, time.Now().UTC(), "user")
+		if err := repo.Save(ctx, mem); err != nil {
+			t.Fatalf("save: %v", err)
+		}
+	}
+
+	seen := map[uuid.UUID]int{}
+	err = repo.IteratePartitions(ctx, 10, func(_ context.Context, pCtx entity.PartitionContext, batch []*entity.EpisodicMemory) error {
+		seen[pCtx.UserID] += len(batch)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("IteratePartitions error: %v", err)
+	}
+	if seen[userA] != 2 {
+		t.Errorf("userA count = %d; want 2", seen[userA])
+	}
+	if seen[userB] != 1 {
+		t.Errorf("userB count = %d; want 1", seen[userB])
+	}
+}
+
+// TestEpisodicRepository_IteratePartitionsSurvivesMidIterationDelete mirrors the
+// Postgres backend's equivalent test: deleting a batch's rows from within the
+// IteratePartitions callback must not cause later rows in the same partition to
+// be skipped, which an offset-based (rather than keyset-based) pager would risk.
+func TestEpisodicRepository_IteratePartitionsSurvivesMidIterationDelete(t *testing.T) {
+	ctx := context.Background()
+	repo, err := NewEpisodicRepository(t.TempDir(), true, 0)
+	if err != nil {
+		t.Fatalf("NewEpisodicRepository error: %v", err)
+	}
+	defer repo.Close()
+
+	userID := uuid.New()
+	const total = 5
+	mems := make([]*entity.EpisodicMemory, total)
+	for i := 0; i < total; i++ {
+		mem := entity.NewEpisodicMemory(userID, nil, "x", []float32{0.1}, time.Now().UTC(), "user")
+		if err := repo.Save(ctx, mem); err != nil {
+			t.Fatalf("save mem %d: %v", i, err)
+		}
+		mems[i] = mem
+	}
+
+	visited := map[uuid.UUID]bool{}
+	const batchSize = 2
+	deleted := 0
+	err = repo.IteratePartitions(ctx, batchSize, func(ctx context.Context, batchPCtx entity.PartitionContext, batch []*entity.EpisodicMemory) error {
+		for _, mem := range batch {
+			visited[mem.ID] = true
+			if deleted < 2 {
+				if err := repo.Delete(ctx, mem.ID, batchPCtx); err != nil {
+					return err
+				}
+				deleted++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("IteratePartitions error: %v", err)
+	}
+
+	for _, mem := range mems {
+		if !visited[mem.ID] {
+			t.Errorf("memory %s was never visited by IteratePartitions", mem.ID)
+		}
+	}
+	if len(visited) != total {
+		t.Errorf("visited %d distinct memories; want %d", len(visited), total)
+	}
+}
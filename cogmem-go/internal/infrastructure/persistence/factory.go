@@ -0,0 +1,62 @@
+// Package persistence selects and constructs the configured
+// repository.EpisodicRepository implementation.
+package persistence
+
+import (
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/lexlapax/cogmem/internal/domain/repository"
+	"github.com/lexlapax/cogmem/internal/domain/service/alarm"
+	"github.com/lexlapax/cogmem/internal/infrastructure/config"
+	"github.com/lexlapax/cogmem/internal/infrastructure/log"
+	"github.com/lexlapax/cogmem/internal/infrastructure/persistence/disk"
+	"github.com/lexlapax/cogmem/internal/infrastructure/persistence/metrics"
+	"github.com/lexlapax/cogmem/internal/infrastructure/persistence/postgres"
+)
+
+// NewEpisodicRepository constructs the EpisodicRepository implementation selected by
+// cfg.Storage.Backend ("postgres", the default, or "disk"), wrapped in a
+// metrics.Repository decorator so every backend reports the same Prometheus metrics.
+// pool is required for the postgres backend and ignored otherwise.
+func NewEpisodicRepository(cfg *config.Config, pool *pgxpool.Pool) (repository.EpisodicRepository, error) {
+	repo, err := newBackend(cfg, pool)
+	if err != nil {
+		return nil, err
+	}
+	return metrics.NewRepository(repo), nil
+}
+
+// NewAlarmMonitor constructs an alarm.Monitor sampling Postgres capacity (row count
+// and pg_database_size) and errorRate, toggling NOSPACE and DEGRADED alarms in the
+// same alarm table consulted by the postgres storage backend's Save. It requires the
+// postgres storage backend, since BadgerDB has no equivalent capacity probe yet.
+func NewAlarmMonitor(cfg *config.Config, pool *pgxpool.Pool, errorRate alarm.ErrorRateProber) (*alarm.Monitor, error) {
+	if cfg.Storage.Backend != "" && cfg.Storage.Backend != "postgres" {
+		return nil, fmt.Errorf("alarm monitor requires the postgres storage backend, got %q", cfg.Storage.Backend)
+	}
+	if pool == nil {
+		return nil, fmt.Errorf("alarm monitor requires a database connection pool")
+	}
+	store := postgres.NewAlarmStore(pool)
+	capacity := postgres.NewCapacityProber(pool)
+	return alarm.New(store, capacity, errorRate, cfg.Alarm.MaxRows, cfg.Alarm.MaxDatabaseBytes, cfg.Alarm.ErrorRateThreshold, cfg.Alarm.Interval), nil
+}
+
+// newBackend constructs the unwrapped backend implementation.
+func newBackend(cfg *config.Config, pool *pgxpool.Pool) (repository.EpisodicRepository, error) {
+	switch cfg.Storage.Backend {
+	case "", "postgres":
+		if pool == nil {
+			return nil, fmt.Errorf("storage backend %q requires a database connection pool", cfg.Storage.Backend)
+		}
+		meta := log.NewMetaLogger(log.Default(), cfg.LogSamplingRate)
+		alarms := postgres.NewAlarmStore(pool)
+		return postgres.NewPostgresEpisodicRepositoryWithOptions(pool, meta, cfg.DecayAccessibilityWeight, alarms), nil
+	case "disk":
+		return disk.NewEpisodicRepository(cfg.Storage.Disk.Directory, cfg.Storage.Disk.AutoCreate, cfg.DecayAccessibilityWeight)
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", cfg.Storage.Backend)
+	}
+}
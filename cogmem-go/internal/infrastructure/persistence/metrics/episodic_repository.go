@@ -0,0 +1,166 @@
+// Package metrics decorates a repository.EpisodicRepository with Prometheus
+// instrumentation, independent of which backend implementation is wrapped.
+package metrics
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/lexlapax/cogmem/internal/domain/entity"
+	"github.com/lexlapax/cogmem/internal/domain/repository"
+)
+
+var (
+	opsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "cogmem_repo_ops_total",
+		Help: "Total EpisodicRepository operations, by operation and status.",
+	}, []string{"op", "status"})
+
+	opDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "cogmem_repo_op_duration_seconds",
+		Help: "EpisodicRepository operation latency in seconds, by operation.",
+	}, []string{"op"})
+
+	embeddingDimMismatches = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "cogmem_embedding_dim_mismatches",
+		Help: "Count of Save calls observed with an embedding dimension different from the first one seen.",
+	})
+
+	partitionCount = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "cogmem_partition_count",
+		Help: "Number of distinct user partitions observed across repository operations.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(opsTotal, opDuration, embeddingDimMismatches, partitionCount)
+}
+
+// Repository wraps a repository.EpisodicRepository, recording Prometheus metrics for
+// every call: op/status counters, per-op latency histograms, and gauges for embedding
+// dimension mismatches and distinct partitions observed.
+type Repository struct {
+	next repository.EpisodicRepository
+
+	mu          sync.Mutex
+	expectedDim int
+	partitions  map[uuid.UUID]bool
+
+	opsOK  uint64
+	opsErr uint64
+}
+
+// NewRepository wraps next with Prometheus instrumentation.
+func NewRepository(next repository.EpisodicRepository) *Repository {
+	return &Repository{next: next, partitions: map[uuid.UUID]bool{}}
+}
+
+// observe records the op/status counter and latency histogram for a single call, and
+// tracks pCtx.UserID in the set of distinct partitions seen.
+func (r *Repository) observe(op string, pCtx entity.PartitionContext, start time.Time, err error) {
+	status := "ok"
+	if err != nil {
+		status = "error"
+		atomic.AddUint64(&r.opsErr, 1)
+	} else {
+		atomic.AddUint64(&r.opsOK, 1)
+	}
+	opsTotal.WithLabelValues(op, status).Inc()
+	opDuration.WithLabelValues(op).Observe(time.Since(start).Seconds())
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.partitions[pCtx.UserID] {
+		r.partitions[pCtx.UserID] = true
+		partitionCount.Set(float64(len(r.partitions)))
+	}
+}
+
+// observeEmbeddingDim records a mismatch if dim differs from the first dimension seen.
+func (r *Repository) observeEmbeddingDim(dim int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.expectedDim == 0 {
+		r.expectedDim = dim
+		return
+	}
+	if dim != r.expectedDim {
+		embeddingDimMismatches.Inc()
+	}
+}
+
+// Save persists mem via the wrapped repository, recording metrics.
+func (r *Repository) Save(ctx context.Context, mem *entity.EpisodicMemory) error {
+	start := time.Now()
+	r.observeEmbeddingDim(len(mem.Embedding))
+	err := r.next.Save(ctx, mem)
+	r.observe("Save", entity.PartitionContext{UserID: mem.UserID, EntityID: mem.EntityID}, start, err)
+	return err
+}
+
+// FindByID retrieves a memory via the wrapped repository, recording metrics.
+func (r *Repository) FindByID(ctx context.Context, id uuid.UUID, pCtx entity.PartitionContext) (*entity.EpisodicMemory, error) {
+	start := time.Now()
+	mem, err := r.next.FindByID(ctx, id, pCtx)
+	r.observe("FindByID", pCtx, start, err)
+	return mem, err
+}
+
+// FindByVector performs a vector search via the wrapped repository, recording metrics.
+func (r *Repository) FindByVector(ctx context.Context, vector []float32, limit int, pCtx entity.PartitionContext) ([]*entity.EpisodicMemory, error) {
+	start := time.Now()
+	results, err := r.next.FindByVector(ctx, vector, limit, pCtx)
+	r.observe("FindByVector", pCtx, start, err)
+	return results, err
+}
+
+// FindRecent retrieves recent memories via the wrapped repository, recording metrics.
+func (r *Repository) FindRecent(ctx context.Context, limit int, pCtx entity.PartitionContext) ([]*entity.EpisodicMemory, error) {
+	start := time.Now()
+	results, err := r.next.FindRecent(ctx, limit, pCtx)
+	r.observe("FindRecent", pCtx, start, err)
+	return results, err
+}
+
+// UpdateAccessibility updates a memory's score via the wrapped repository, recording metrics.
+func (r *Repository) UpdateAccessibility(ctx context.Context, id uuid.UUID, score float64, pCtx entity.PartitionContext) error {
+	start := time.Now()
+	err := r.next.UpdateAccessibility(ctx, id, score, pCtx)
+	r.observe("UpdateAccessibility", pCtx, start, err)
+	return err
+}
+
+// Delete removes a memory via the wrapped repository, recording metrics.
+func (r *Repository) Delete(ctx context.Context, id uuid.UUID, pCtx entity.PartitionContext) error {
+	start := time.Now()
+	err := r.next.Delete(ctx, id, pCtx)
+	r.observe("Delete", pCtx, start, err)
+	return err
+}
+
+// IteratePartitions delegates to the wrapped repository; batch processing isn't
+// attributable to a single partition up front, so it is not separately instrumented.
+func (r *Repository) IteratePartitions(ctx context.Context, batchSize int, fn repository.PartitionBatchFunc) error {
+	return r.next.IteratePartitions(ctx, batchSize, fn)
+}
+
+// ErrorRate returns the fraction of calls observed so far that failed, as a
+// value between 0 and 1, implementing alarm.ErrorRateProber for the DEGRADED
+// alarm. It never errors.
+func (r *Repository) ErrorRate(ctx context.Context) (float64, error) {
+	ok := atomic.LoadUint64(&r.opsOK)
+	errs := atomic.LoadUint64(&r.opsErr)
+	total := ok + errs
+	if total == 0 {
+		return 0, nil
+	}
+	return float64(errs) / float64(total), nil
+}
+
+// Ensure Repository satisfies the interface.
+var _ repository.EpisodicRepository = (*Repository)(nil)
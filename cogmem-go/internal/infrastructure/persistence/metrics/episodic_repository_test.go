@@ -0,0 +1,93 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/lexlapax/cogmem/internal/domain/entity"
+	"github.com/lexlapax/cogmem/internal/domain/repository"
+)
+
+var errTest = errors.New("boom")
+
+// fakeRepository is a minimal in-memory repository.EpisodicRepository used to
+// exercise the metrics decorator without a real storage backend.
+type fakeRepository struct {
+	saveErr error
+}
+
+func (r *fakeRepository) Save(ctx context.Context, mem *entity.EpisodicMemory) error {
+	return r.saveErr
+}
+func (r *fakeRepository) FindByID(ctx context.Context, id uuid.UUID, pCtx entity.PartitionContext) (*entity.EpisodicMemory, error) {
+	return nil, nil
+}
+func (r *fakeRepository) FindByVector(ctx context.Context, vector []float32, limit int, pCtx entity.PartitionContext) ([]*entity.EpisodicMemory, error) {
+	return nil, nil
+}
+func (r *fakeRepository) FindRecent(ctx context.Context, limit int, pCtx entity.PartitionContext) ([]*entity.EpisodicMemory, error) {
+	return nil, nil
+}
+func (r *fakeRepository) UpdateAccessibility(ctx context.Context, id uuid.UUID, score float64, pCtx entity.PartitionContext) error {
+	return nil
+}
+func (r *fakeRepository) Delete(ctx context.Context, id uuid.UUID, pCtx entity.PartitionContext) error {
+	return nil
+}
+func (r *fakeRepository) IteratePartitions(ctx context.Context, batchSize int, fn repository.PartitionBatchFunc) error {
+	return nil
+}
+
+var _ repository.EpisodicRepository = (*fakeRepository)(nil)
+
+func TestRepositorySaveRecordsMetrics(t *testing.T) {
+	repo := NewRepository(&fakeRepository{})
+	userID := uuid.New()
+	mem := entity.NewEpisodicMemory(userID, nil, "x", []float32{0.1, 0.2}, time.Now().UTC(), "user")
+
+	if err := repo.Save(context.Background(), mem); err != nil {
+		t.Fatalf("Save error: %v", err)
+	}
+
+	got := testutil.ToFloat64(opsTotal.WithLabelValues("Save", "ok"))
+	if got < 1 {
+		t.Errorf("cogmem_repo_ops_total{op=Save,status=ok} = %v; want >= 1", got)
+	}
+}
+
+func TestRepositorySaveErrorRecordsFailureStatus(t *testing.T) {
+	wantErr := errTest
+	repo := NewRepository(&fakeRepository{saveErr: wantErr})
+	mem := entity.NewEpisodicMemory(uuid.New(), nil, "x", []float32{0.1}, time.Now().UTC(), "user")
+
+	if err := repo.Save(context.Background(), mem); err != wantErr {
+		t.Fatalf("expected wantErr, got %v", err)
+	}
+
+	got := testutil.ToFloat64(opsTotal.WithLabelValues("Save", "error"))
+	if got < 1 {
+		t.Errorf("cogmem_repo_ops_total{op=Save,status=error} = %v; want >= 1", got)
+	}
+}
+
+func TestRepositoryErrorRate(t *testing.T) {
+	repo := NewRepository(&fakeRepository{saveErr: errTest})
+	mem := entity.NewEpisodicMemory(uuid.New(), nil, "x", []float32{0.1}, time.Now().UTC(), "user")
+
+	for i := 0; i < 3; i++ {
+		_ = repo.Save(context.Background(), mem)
+	}
+
+	rate, err := repo.ErrorRate(context.Background())
+	if err != nil {
+		t.Fatalf("ErrorRate error: %v", err)
+	}
+	if rate != 1 {
+		t.Errorf("ErrorRate = %v; want 1", rate)
+	}
+}
@@ -0,0 +1,283 @@
+// Package migrate discovers and applies CogMem's Postgres schema migrations.
+// Migration files are embedded at build time and applied under a Postgres
+// advisory lock so that concurrent service startups don't race.
+package migrate
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// advisoryLockKey namespaces the Postgres advisory lock used to serialize
+// migration runs across concurrent service startups.
+const advisoryLockKey int64 = 847162534
+
+// schemaMigrationsDDL creates the bookkeeping table used to track applied
+// migration versions.
+const schemaMigrationsDDL = `CREATE TABLE IF NOT EXISTS schema_migrations (
+	version BIGINT PRIMARY KEY,
+	name TEXT NOT NULL,
+	applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+)`
+
+// Migration is a single discovered NNNN_name.{up,down}.sql pair.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// StatusEntry describes one migration's applied state.
+type StatusEntry struct {
+	Version int
+	Name    string
+	Applied bool
+}
+
+// Load discovers migrations embedded under migrations/, sorted by version.
+func Load() ([]Migration, error) {
+	entries, err := fs.ReadDir(migrationFiles, "migrations")
+	if err != nil {
+		return nil, fmt.Errorf("read embedded migrations: %w", err)
+	}
+
+	byVersion := map[int]*Migration{}
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		name := e.Name()
+		var kind string
+		switch {
+		case strings.HasSuffix(name, ".up.sql"):
+			kind = "up"
+		case strings.HasSuffix(name, ".down.sql"):
+			kind = "down"
+		default:
+			continue
+		}
+		base := strings.TrimSuffix(strings.TrimSuffix(name, ".up.sql"), ".down.sql")
+		parts := strings.SplitN(base, "_", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("malformed migration filename %q", name)
+		}
+		version, err := strconv.Atoi(parts[0])
+		if err != nil {
+			return nil, fmt.Errorf("malformed migration version in %q: %w", name, err)
+		}
+		content, err := migrationFiles.ReadFile("migrations/" + name)
+		if err != nil {
+			return nil, fmt.Errorf("read migration %q: %w", name, err)
+		}
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: parts[1]}
+			byVersion[version] = m
+		}
+		if kind == "up" {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Run applies all pending migrations in order. Intended to be called during
+// service startup.
+func Run(ctx context.Context, pool *pgxpool.Pool) error {
+	return Up(ctx, pool)
+}
+
+// Up applies all pending migrations in order, guarded by a Postgres advisory
+// lock so concurrent callers don't apply the same migration twice.
+func Up(ctx context.Context, pool *pgxpool.Pool) error {
+	migrations, err := Load()
+	if err != nil {
+		return err
+	}
+	return withAdvisoryLock(ctx, pool, func(conn *pgxpool.Conn) error {
+		applied, err := preparedState(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, m := range migrations {
+			if applied[m.Version] {
+				continue
+			}
+			if err := applyMigration(ctx, conn, m, true); err != nil {
+				return fmt.Errorf("apply migration %04d_%s: %w", m.Version, m.Name, err)
+			}
+		}
+		return nil
+	})
+}
+
+// Down rolls back the single most recently applied migration.
+func Down(ctx context.Context, pool *pgxpool.Pool) error {
+	migrations, err := Load()
+	if err != nil {
+		return err
+	}
+	byVersion := make(map[int]Migration, len(migrations))
+	for _, m := range migrations {
+		byVersion[m.Version] = m
+	}
+	return withAdvisoryLock(ctx, pool, func(conn *pgxpool.Conn) error {
+		applied, err := preparedState(ctx, conn)
+		if err != nil {
+			return err
+		}
+		latest := -1
+		for v := range applied {
+			if v > latest {
+				latest = v
+			}
+		}
+		if latest == -1 {
+			return nil
+		}
+		m, ok := byVersion[latest]
+		if !ok {
+			return fmt.Errorf("no migration source found for applied version %d", latest)
+		}
+		return applyMigration(ctx, conn, m, false)
+	})
+}
+
+// To migrates up or down until exactly the migrations at or below version are
+// applied.
+func To(ctx context.Context, pool *pgxpool.Pool, version int) error {
+	migrations, err := Load()
+	if err != nil {
+		return err
+	}
+	return withAdvisoryLock(ctx, pool, func(conn *pgxpool.Conn) error {
+		applied, err := preparedState(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, m := range migrations {
+			if m.Version <= version && !applied[m.Version] {
+				if err := applyMigration(ctx, conn, m, true); err != nil {
+					return fmt.Errorf("apply migration %04d_%s: %w", m.Version, m.Name, err)
+				}
+			}
+		}
+		for i := len(migrations) - 1; i >= 0; i-- {
+			m := migrations[i]
+			if m.Version > version && applied[m.Version] {
+				if err := applyMigration(ctx, conn, m, false); err != nil {
+					return fmt.Errorf("revert migration %04d_%s: %w", m.Version, m.Name, err)
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// Status reports the applied state of every discovered migration.
+func Status(ctx context.Context, pool *pgxpool.Pool) ([]StatusEntry, error) {
+	migrations, err := Load()
+	if err != nil {
+		return nil, err
+	}
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("acquire connection: %w", err)
+	}
+	defer conn.Release()
+	applied, err := preparedState(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	report := make([]StatusEntry, len(migrations))
+	for i, m := range migrations {
+		report[i] = StatusEntry{Version: m.Version, Name: m.Name, Applied: applied[m.Version]}
+	}
+	return report, nil
+}
+
+// preparedState ensures the schema_migrations table exists and returns the
+// set of currently applied versions.
+func preparedState(ctx context.Context, conn *pgxpool.Conn) (map[int]bool, error) {
+	if _, err := conn.Exec(ctx, schemaMigrationsDDL); err != nil {
+		return nil, fmt.Errorf("create schema_migrations table: %w", err)
+	}
+	rows, err := conn.Query(ctx, "SELECT version FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("query schema_migrations: %w", err)
+	}
+	defer rows.Close()
+	applied := map[int]bool{}
+	for rows.Next() {
+		var v int
+		if err := rows.Scan(&v); err != nil {
+			return nil, fmt.Errorf("scan schema_migrations row: %w", err)
+		}
+		applied[v] = true
+	}
+	return applied, rows.Err()
+}
+
+// applyMigration runs a single migration's up or down script and updates
+// schema_migrations, both inside one transaction.
+func applyMigration(ctx context.Context, conn *pgxpool.Conn, m Migration, up bool) error {
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if up {
+		if _, err := tx.Exec(ctx, m.Up); err != nil {
+			return fmt.Errorf("run up script: %w", err)
+		}
+		if _, err := tx.Exec(ctx, "INSERT INTO schema_migrations (version, name) VALUES ($1, $2)", m.Version, m.Name); err != nil {
+			return fmt.Errorf("record migration: %w", err)
+		}
+	} else {
+		if _, err := tx.Exec(ctx, m.Down); err != nil {
+			return fmt.Errorf("run down script: %w", err)
+		}
+		if _, err := tx.Exec(ctx, "DELETE FROM schema_migrations WHERE version = $1", m.Version); err != nil {
+			return fmt.Errorf("unrecord migration: %w", err)
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// withAdvisoryLock acquires a dedicated connection, takes the migration
+// advisory lock on it, runs fn, and releases the lock before returning the
+// connection to the pool.
+func withAdvisoryLock(ctx context.Context, pool *pgxpool.Pool, fn func(conn *pgxpool.Conn) error) error {
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", advisoryLockKey); err != nil {
+		return fmt.Errorf("acquire advisory lock: %w", err)
+	}
+	defer conn.Exec(ctx, "SELECT pg_advisory_unlock($1)", advisoryLockKey)
+
+	return fn(conn)
+}
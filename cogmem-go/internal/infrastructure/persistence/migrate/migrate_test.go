@@ -0,0 +1,26 @@
+package migrate
+
+import "testing"
+
+func TestLoadDiscoversMigrations(t *testing.T) {
+	migrations, err := Load()
+	if err != nil {
+		t.Fatalf("Load error: %v", err)
+	}
+	if len(migrations) == 0 {
+		t.Fatal("expected at least one migration")
+	}
+	first := migrations[0]
+	if first.Version != 1 {
+		t.Errorf("first migration version = %d; want 1", first.Version)
+	}
+	if first.Name != "create_episodic_memory_table" {
+		t.Errorf("first migration name = %q; want %q", first.Name, "create_episodic_memory_table")
+	}
+	if first.Up == "" {
+		t.Error("expected non-empty up script")
+	}
+	if first.Down == "" {
+		t.Error("expected non-empty down script")
+	}
+}
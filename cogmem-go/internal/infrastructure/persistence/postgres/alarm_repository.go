@@ -0,0 +1,108 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/lexlapax/cogmem/internal/domain/repository"
+)
+
+// AlarmStore implements repository.AlarmStore using PostgreSQL.
+type AlarmStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewAlarmStore constructs a new Postgres-backed AlarmStore.
+func NewAlarmStore(pool *pgxpool.Pool) *AlarmStore {
+	return &AlarmStore{pool: pool}
+}
+
+// Activate raises an alarm of the given type, inserting a new row or updating
+// the existing one's level and details in place.
+func (s *AlarmStore) Activate(ctx context.Context, alarmType repository.AlarmType, level repository.AlarmLevel, details string) error {
+	const sql = `INSERT INTO alarm (id, type, level, since, details)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (type) DO UPDATE SET level = EXCLUDED.level, details = EXCLUDED.details`
+	if _, err := s.pool.Exec(ctx, sql, uuid.New(), alarmType, level, time.Now().UTC(), details); err != nil {
+		return fmt.Errorf("activate alarm %s: %w", alarmType, err)
+	}
+	return nil
+}
+
+// Deactivate clears an alarm of the given type, if one is active.
+func (s *AlarmStore) Deactivate(ctx context.Context, alarmType repository.AlarmType) error {
+	if _, err := s.pool.Exec(ctx, `DELETE FROM alarm WHERE type = $1`, alarmType); err != nil {
+		return fmt.Errorf("deactivate alarm %s: %w", alarmType, err)
+	}
+	return nil
+}
+
+// Get returns the active alarm of the given type, or nil if none is active.
+func (s *AlarmStore) Get(ctx context.Context, alarmType repository.AlarmType) (*repository.AlarmMember, error) {
+	const sql = `SELECT id, type, level, since, details FROM alarm WHERE type = $1`
+	var member repository.AlarmMember
+	err := s.pool.QueryRow(ctx, sql, alarmType).Scan(&member.ID, &member.Type, &member.Level, &member.Since, &member.Details)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get alarm %s: %w", alarmType, err)
+	}
+	return &member, nil
+}
+
+// List returns every currently active alarm.
+func (s *AlarmStore) List(ctx context.Context) ([]repository.AlarmMember, error) {
+	rows, err := s.pool.Query(ctx, `SELECT id, type, level, since, details FROM alarm ORDER BY since`)
+	if err != nil {
+		return nil, fmt.Errorf("list alarms: %w", err)
+	}
+	defer rows.Close()
+	var members []repository.AlarmMember
+	for rows.Next() {
+		var member repository.AlarmMember
+		if err := rows.Scan(&member.ID, &member.Type, &member.Level, &member.Since, &member.Details); err != nil {
+			return nil, fmt.Errorf("scan alarm row: %w", err)
+		}
+		members = append(members, member)
+	}
+	return members, rows.Err()
+}
+
+// Ensure AlarmStore satisfies the interface.
+var _ repository.AlarmStore = (*AlarmStore)(nil)
+
+// CapacityProber implements alarm.CapacityProber by querying the
+// episodic_memory table's row count and the database's on-disk size.
+type CapacityProber struct {
+	pool *pgxpool.Pool
+}
+
+// NewCapacityProber constructs a Postgres-backed CapacityProber.
+func NewCapacityProber(pool *pgxpool.Pool) *CapacityProber {
+	return &CapacityProber{pool: pool}
+}
+
+// RowCount returns the number of rows in episodic_memory.
+func (p *CapacityProber) RowCount(ctx context.Context) (int64, error) {
+	var count int64
+	if err := p.pool.QueryRow(ctx, `SELECT COUNT(*) FROM episodic_memory`).Scan(&count); err != nil {
+		return 0, fmt.Errorf("count episodic_memory rows: %w", err)
+	}
+	return count, nil
+}
+
+// DatabaseSizeBytes returns the current database's on-disk size, as reported
+// by pg_database_size.
+func (p *CapacityProber) DatabaseSizeBytes(ctx context.Context) (int64, error) {
+	var size int64
+	if err := p.pool.QueryRow(ctx, `SELECT pg_database_size(current_database())`).Scan(&size); err != nil {
+		return 0, fmt.Errorf("query pg_database_size: %w", err)
+	}
+	return size, nil
+}
@@ -10,101 +10,267 @@ import (
 
    "github.com/lexlapax/cogmem/internal/domain/entity"
    "github.com/lexlapax/cogmem/internal/domain/repository"
+   "github.com/lexlapax/cogmem/internal/domain/service/alarm"
+   "github.com/lexlapax/cogmem/internal/infrastructure/log"
 )
 
 // PostgresEpisodicRepository implements repository.EpisodicRepository using PostgreSQL.
 type PostgresEpisodicRepository struct {
-   pool *pgxpool.Pool
+   pool                *pgxpool.Pool
+   meta                *log.MetaLogger
+   accessibilityWeight float64
+   alarms              repository.AlarmStore
 }
 
-// NewPostgresEpisodicRepository constructs a new Postgres-backed EpisodicRepository.
+// NewPostgresEpisodicRepository constructs a new Postgres-backed EpisodicRepository,
+// logging one structured event per call via a MetaLogger wrapping log.Default(), and
+// with accessibility-score boosting and alarm checks disabled.
 func NewPostgresEpisodicRepository(pool *pgxpool.Pool) *PostgresEpisodicRepository {
-   return &PostgresEpisodicRepository{pool: pool}
+   return NewPostgresEpisodicRepositoryWithOptions(pool, log.NewMetaLogger(log.Default(), 1.0), 0, nil)
 }
 
-// Save persists a new EpisodicMemory record.
+// NewPostgresEpisodicRepositoryWithOptions constructs a Postgres-backed EpisodicRepository
+// using the given MetaLogger (e.g. to apply a sampling rate on hot paths like
+// FindByVector), accessibilityWeight (how strongly AccessibilityScore boosts
+// FindByVector/FindRecent ordering), and alarms store consulted by Save before
+// each write. alarms may be nil to disable alarm checks.
+func NewPostgresEpisodicRepositoryWithOptions(pool *pgxpool.Pool, meta *log.MetaLogger, accessibilityWeight float64, alarms repository.AlarmStore) *PostgresEpisodicRepository {
+   return &PostgresEpisodicRepository{pool: pool, meta: meta, accessibilityWeight: accessibilityWeight, alarms: alarms}
+}
+
+// Save persists a new EpisodicMemory record, rejecting the write with
+// alarm.ErrAlarmActive if a critical NOSPACE alarm is currently active. The alarm check
+// runs inside the MetaLogger call so a rejection is logged the same as any
+// other Save failure, rather than short-circuiting before any event is emitted.
 func (r *PostgresEpisodicRepository) Save(ctx context.Context, mem *entity.EpisodicMemory) error {
-   vec := pgvector.NewVector(mem.Embedding)
-   const sql = `INSERT INTO episodic_memory
-       (id, user_id, entity_id, content, embedding, timestamp, share_scope, last_accessed, accessibility_score)
-       VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)`
-   if _, err := r.pool.Exec(ctx, sql,
-       mem.ID, mem.UserID, mem.EntityID,
-       mem.Content, vec,
-       mem.Timestamp, mem.ShareScope,
-       mem.LastAccessed, mem.AccessibilityScore,
-   ); err != nil {
-       return fmt.Errorf("insert episodic_memory: %w", err)
-   }
-   return nil
+   pCtx := entity.PartitionContext{UserID: mem.UserID, EntityID: mem.EntityID}
+   return r.meta.Call(ctx, "Save", pCtx, func() (int, error) {
+       if r.alarms != nil {
+           active, err := r.alarms.Get(ctx, repository.AlarmNoSpace)
+           if err != nil {
+               return 0, fmt.Errorf("check NOSPACE alarm: %w", err)
+           }
+           if active != nil && active.Level == repository.LevelCritical {
+               return 0, alarm.ErrAlarmActive{Type: repository.AlarmNoSpace}
+           }
+       }
+       vec := pgvector.NewVector(mem.Embedding)
+       const sql = `INSERT INTO episodic_memory
+           (id, user_id, entity_id, content, embedding, timestamp, share_scope, last_accessed, accessibility_score, valence)
+           VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10)`
+       if _, err := r.pool.Exec(ctx, sql,
+           mem.ID, mem.UserID, mem.EntityID,
+           mem.Content, vec,
+           mem.Timestamp, mem.ShareScope,
+           mem.LastAccessed, mem.AccessibilityScore, mem.Valence,
+       ); err != nil {
+           return 0, fmt.Errorf("insert episodic_memory: %w", err)
+       }
+       return 1, nil
+   })
 }
 
 // FindByID retrieves a memory by ID within the given partition.
 func (r *PostgresEpisodicRepository) FindByID(ctx context.Context, id uuid.UUID, pCtx entity.PartitionContext) (*entity.EpisodicMemory, error) {
-   const sql = `SELECT id, user_id, entity_id, content, embedding, timestamp, share_scope, last_accessed, accessibility_score
-       FROM episodic_memory
-       WHERE id = $1
-         AND user_id = $2
-         AND (entity_id IS NULL OR entity_id = $3)`
-   row := r.pool.QueryRow(ctx, sql, id, pCtx.UserID, pCtx.EntityID)
    var mem entity.EpisodicMemory
-   var vec pgvector.Vector
-   err := row.Scan(
-       &mem.ID, &mem.UserID, &mem.EntityID,
-       &mem.Content, &vec,
-       &mem.Timestamp, &mem.ShareScope,
-       &mem.LastAccessed, &mem.AccessibilityScore,
-   )
+   err := r.meta.Call(ctx, "FindByID", pCtx, func() (int, error) {
+       const sql = `SELECT id, user_id, entity_id, content, embedding, timestamp, share_scope, last_accessed, accessibility_score, valence
+           FROM episodic_memory
+           WHERE id = $1
+             AND user_id = $2
+             AND (entity_id IS NULL OR entity_id = $3)`
+       row := r.pool.QueryRow(ctx, sql, id, pCtx.UserID, pCtx.EntityID)
+       var vec pgvector.Vector
+       if err := row.Scan(
+           &mem.ID, &mem.UserID, &mem.EntityID,
+           &mem.Content, &vec,
+           &mem.Timestamp, &mem.ShareScope,
+           &mem.LastAccessed, &mem.AccessibilityScore, &mem.Valence,
+       ); err != nil {
+           return 0, err
+       }
+       mem.Embedding = vec.Slice()
+       return 1, nil
+   })
    if err != nil {
        return nil, err
    }
-   mem.Embedding = vec.Slice()
    return &mem, nil
 }
 
-// FindRecent returns the most recent memories in the partition, up to limit.
+// FindRecent returns the most recent memories in the partition, up to limit, boosted by
+// AccessibilityScore according to the repository's configured accessibility weight. The
+// recency term is expressed in hours relative to now() rather than a raw epoch, so weight
+// reads as "how many hours of extra age a fully-accessible memory can overcome" and stays
+// comparable to the weight values sane for FindByVector's [0,2] cosine distance.
 func (r *PostgresEpisodicRepository) FindRecent(ctx context.Context, limit int, pCtx entity.PartitionContext) ([]*entity.EpisodicMemory, error) {
-   const sql = `SELECT id, user_id, entity_id, content, embedding, timestamp, share_scope, last_accessed, accessibility_score
-       FROM episodic_memory
-       WHERE user_id = $1
-         AND (entity_id IS NULL OR entity_id = $2)
-       ORDER BY timestamp DESC
-       LIMIT $3`
-   rows, err := r.pool.Query(ctx, sql, pCtx.UserID, pCtx.EntityID, limit)
+   var results []*entity.EpisodicMemory
+   err := r.meta.Call(ctx, "FindRecent", pCtx, func() (int, error) {
+       const sql = `SELECT id, user_id, entity_id, content, embedding, timestamp, share_scope, last_accessed, accessibility_score, valence
+           FROM episodic_memory
+           WHERE user_id = $1
+             AND (entity_id IS NULL OR entity_id = $2)
+           ORDER BY (EXTRACT(EPOCH FROM (timestamp - now())) / 3600.0 + $3 * accessibility_score) DESC
+           LIMIT $4`
+       rows, err := r.pool.Query(ctx, sql, pCtx.UserID, pCtx.EntityID, r.accessibilityWeight, limit)
+       if err != nil {
+           return 0, fmt.Errorf("query recent: %w", err)
+       }
+       defer rows.Close()
+       for rows.Next() {
+           var mem entity.EpisodicMemory
+           var vec pgvector.Vector
+           if err := rows.Scan(
+               &mem.ID, &mem.UserID, &mem.EntityID,
+               &mem.Content, &vec,
+               &mem.Timestamp, &mem.ShareScope,
+               &mem.LastAccessed, &mem.AccessibilityScore, &mem.Valence,
+           ); err != nil {
+               return 0, fmt.Errorf("scan recent: %w", err)
+           }
+           mem.Embedding = vec.Slice()
+           results = append(results, &mem)
+       }
+       return len(results), nil
+   })
    if err != nil {
-       return nil, fmt.Errorf("query recent: %w", err)
+       return nil, err
    }
-   defer rows.Close()
+   return results, nil
+}
+
+// FindByVector performs a vector similarity search within the given partition, boosted by
+// AccessibilityScore according to the repository's configured accessibility weight.
+func (r *PostgresEpisodicRepository) FindByVector(ctx context.Context, vector []float32, limit int, pCtx entity.PartitionContext) ([]*entity.EpisodicMemory, error) {
    var results []*entity.EpisodicMemory
-   for rows.Next() {
-       var mem entity.EpisodicMemory
-       var vec pgvector.Vector
-       if err := rows.Scan(
-           &mem.ID, &mem.UserID, &mem.EntityID,
-           &mem.Content, &vec,
-           &mem.Timestamp, &mem.ShareScope,
-           &mem.LastAccessed, &mem.AccessibilityScore,
-       ); err != nil {
-           return nil, fmt.Errorf("scan recent: %w", err)
+   err := r.meta.Call(ctx, "FindByVector", pCtx, func() (int, error) {
+       const sql = `SELECT id, user_id, entity_id, content, embedding, timestamp, share_scope, last_accessed, accessibility_score, valence
+           FROM episodic_memory
+           WHERE user_id = $1
+             AND (entity_id IS NULL OR entity_id = $2)
+           ORDER BY (embedding <=> $3) - $4 * accessibility_score
+           LIMIT $5`
+       vecParam := pgvector.NewVector(vector)
+       rows, err := r.pool.Query(ctx, sql, pCtx.UserID, pCtx.EntityID, vecParam, r.accessibilityWeight, limit)
+       if err != nil {
+           return 0, fmt.Errorf("vector search: %w", err)
        }
-       mem.Embedding = vec.Slice()
-       results = append(results, &mem)
+       defer rows.Close()
+       for rows.Next() {
+           var mem entity.EpisodicMemory
+           var vec pgvector.Vector
+           if err := rows.Scan(
+               &mem.ID, &mem.UserID, &mem.EntityID,
+               &mem.Content, &vec,
+               &mem.Timestamp, &mem.ShareScope,
+               &mem.LastAccessed, &mem.AccessibilityScore, &mem.Valence,
+           ); err != nil {
+               return 0, fmt.Errorf("scan vector row: %w", err)
+           }
+           mem.Embedding = vec.Slice()
+           results = append(results, &mem)
+       }
+       return len(results), nil
+   })
+   if err != nil {
+       return nil, err
    }
    return results, nil
 }
 
-// FindByVector performs a vector similarity search within the given partition.
-func (r *PostgresEpisodicRepository) FindByVector(ctx context.Context, vector []float32, limit int, pCtx entity.PartitionContext) ([]*entity.EpisodicMemory, error) {
-   const sql = `SELECT id, user_id, entity_id, content, embedding, timestamp, share_scope, last_accessed, accessibility_score
+// UpdateAccessibility updates a memory's AccessibilityScore within the given partition.
+func (r *PostgresEpisodicRepository) UpdateAccessibility(ctx context.Context, id uuid.UUID, score float64, pCtx entity.PartitionContext) error {
+   return r.meta.Call(ctx, "UpdateAccessibility", pCtx, func() (int, error) {
+       const sql = `UPDATE episodic_memory
+           SET accessibility_score = $1
+           WHERE id = $2
+             AND user_id = $3
+             AND (entity_id IS NULL OR entity_id = $4)`
+       tag, err := r.pool.Exec(ctx, sql, score, id, pCtx.UserID, pCtx.EntityID)
+       if err != nil {
+           return 0, fmt.Errorf("update accessibility: %w", err)
+       }
+       return int(tag.RowsAffected()), nil
+   })
+}
+
+// Delete removes a memory by ID within the given partition.
+func (r *PostgresEpisodicRepository) Delete(ctx context.Context, id uuid.UUID, pCtx entity.PartitionContext) error {
+   return r.meta.Call(ctx, "Delete", pCtx, func() (int, error) {
+       const sql = `DELETE FROM episodic_memory
+           WHERE id = $1
+             AND user_id = $2
+             AND (entity_id IS NULL OR entity_id = $3)`
+       tag, err := r.pool.Exec(ctx, sql, id, pCtx.UserID, pCtx.EntityID)
+       if err != nil {
+           return 0, fmt.Errorf("delete episodic_memory: %w", err)
+       }
+       return int(tag.RowsAffected()), nil
+   })
+}
+
+// IteratePartitions walks every stored (user_id, entity_id) partition's memories in
+// batches of batchSize, invoking fn once per batch. Used by the decay subsystem to
+// rescore AccessibilityScore without loading an entire table into memory at once.
+func (r *PostgresEpisodicRepository) IteratePartitions(ctx context.Context, batchSize int, fn repository.PartitionBatchFunc) error {
+   rows, err := r.pool.Query(ctx, `SELECT DISTINCT user_id, entity_id FROM episodic_memory`)
+   if err != nil {
+       return fmt.Errorf("query partitions: %w", err)
+   }
+   type partitionKey struct {
+       userID   uuid.UUID
+       entityID *uuid.UUID
+   }
+   var partitions []partitionKey
+   for rows.Next() {
+       var p partitionKey
+       if err := rows.Scan(&p.userID, &p.entityID); err != nil {
+           rows.Close()
+           return fmt.Errorf("scan partition: %w", err)
+       }
+       partitions = append(partitions, p)
+   }
+   rows.Close()
+   if err := rows.Err(); err != nil {
+       return fmt.Errorf("iterate partitions: %w", err)
+   }
+
+   for _, p := range partitions {
+       pCtx := entity.PartitionContext{UserID: p.userID, EntityID: p.entityID}
+       lastID := uuid.Nil
+       for {
+           batch, err := r.findBatch(ctx, pCtx, batchSize, lastID)
+           if err != nil {
+               return fmt.Errorf("find batch for partition %s: %w", p.userID, err)
+           }
+           if len(batch) == 0 {
+               break
+           }
+           if err := fn(ctx, pCtx, batch); err != nil {
+               return fmt.Errorf("process batch for partition %s: %w", p.userID, err)
+           }
+           if len(batch) < batchSize {
+               break
+           }
+           lastID = batch[len(batch)-1].ID
+       }
+   }
+   return nil
+}
+
+// findBatch fetches one page of a partition's memories with ID greater than afterID,
+// ordered by ID. Using a keyset on the last ID actually returned, rather than an
+// OFFSET, means fn deleting already-visited rows (as decay.Decayer does) can't shift
+// later rows out from under the next page.
+func (r *PostgresEpisodicRepository) findBatch(ctx context.Context, pCtx entity.PartitionContext, limit int, afterID uuid.UUID) ([]*entity.EpisodicMemory, error) {
+   const sql = `SELECT id, user_id, entity_id, content, embedding, timestamp, share_scope, last_accessed, accessibility_score, valence
        FROM episodic_memory
-       WHERE user_id = $1
-         AND (entity_id IS NULL OR entity_id = $2)
-       ORDER BY embedding <=> $3
+       WHERE user_id = $1 AND entity_id IS NOT DISTINCT FROM $2 AND id > $3
+       ORDER BY id
        LIMIT $4`
-   vecParam := pgvector.NewVector(vector)
-   rows, err := r.pool.Query(ctx, sql, pCtx.UserID, pCtx.EntityID, vecParam, limit)
+   rows, err := r.pool.Query(ctx, sql, pCtx.UserID, pCtx.EntityID, afterID, limit)
    if err != nil {
-       return nil, fmt.Errorf("vector search: %w", err)
+       return nil, err
    }
    defer rows.Close()
    var results []*entity.EpisodicMemory
@@ -115,9 +281,9 @@ func (r *PostgresEpisodicRepository) FindByVector(ctx context.Context, vector []
            &mem.ID, &mem.UserID, &mem.EntityID,
            &mem.Content, &vec,
            &mem.Timestamp, &mem.ShareScope,
-           &mem.LastAccessed, &mem.AccessibilityScore,
+           &mem.LastAccessed, &mem.AccessibilityScore, &mem.Valence,
        ); err != nil {
-           return nil, fmt.Errorf("scan vector row: %w", err)
+           return nil, err
        }
        mem.Embedding = vec.Slice()
        results = append(results, &mem)
@@ -126,4 +292,4 @@ func (r *PostgresEpisodicRepository) FindByVector(ctx context.Context, vector []
 }
 
 // Ensure PostgresEpisodicRepository satisfies the interface
-var _ repository.EpisodicRepository = (*PostgresEpisodicRepository)(nil)
\ No newline at end of file
+var _ repository.EpisodicRepository = (*PostgresEpisodicRepository)(nil)
@@ -0,0 +1,83 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/lexlapax/cogmem/internal/domain/entity"
+	"github.com/lexlapax/cogmem/internal/domain/repository"
+	"github.com/lexlapax/cogmem/internal/domain/service/alarm"
+	"github.com/lexlapax/cogmem/internal/infrastructure/log"
+)
+
+// fakeAlarmStore is a minimal in-memory repository.AlarmStore used to exercise
+// Save's alarm check without a real database.
+type fakeAlarmStore struct {
+	active map[repository.AlarmType]repository.AlarmMember
+}
+
+func newFakeAlarmStore() *fakeAlarmStore {
+	return &fakeAlarmStore{active: map[repository.AlarmType]repository.AlarmMember{}}
+}
+
+func (s *fakeAlarmStore) Activate(ctx context.Context, alarmType repository.AlarmType, level repository.AlarmLevel, details string) error {
+	member, ok := s.active[alarmType]
+	if !ok {
+		member = repository.AlarmMember{ID: uuid.New(), Type: alarmType, Since: time.Now().UTC()}
+	}
+	member.Level = level
+	member.Details = details
+	s.active[alarmType] = member
+	return nil
+}
+
+func (s *fakeAlarmStore) Deactivate(ctx context.Context, alarmType repository.AlarmType) error {
+	delete(s.active, alarmType)
+	return nil
+}
+
+func (s *fakeAlarmStore) Get(ctx context.Context, alarmType repository.AlarmType) (*repository.AlarmMember, error) {
+	member, ok := s.active[alarmType]
+	if !ok {
+		return nil, nil
+	}
+	return &member, nil
+}
+
+func (s *fakeAlarmStore) List(ctx context.Context) ([]repository.AlarmMember, error) {
+	var members []repository.AlarmMember
+	for _, member := range s.active {
+		members = append(members, member)
+	}
+	return members, nil
+}
+
+var _ repository.AlarmStore = (*fakeAlarmStore)(nil)
+
+// TestSaveRejectsWriteWhileNoSpaceAlarmActive verifies Save returns
+// alarm.ErrAlarmActive, without touching the database, when a NOSPACE alarm is
+// active. The alarm check runs first inside the MetaLogger call, so a real
+// pool connection is never required for this path.
+func TestSaveRejectsWriteWhileNoSpaceAlarmActive(t *testing.T) {
+	ctx := context.Background()
+	alarms := newFakeAlarmStore()
+	if err := alarms.Activate(ctx, repository.AlarmNoSpace, repository.LevelCritical, "disk full"); err != nil {
+		t.Fatalf("activate alarm: %v", err)
+	}
+	repo := NewPostgresEpisodicRepositoryWithOptions(nil, log.NewMetaLogger(log.Default(), 0), 0, alarms)
+
+	mem := entity.NewEpisodicMemory(uuid.New(), nil, "content", []float32{0.1}, time.Now().UTC(), "user")
+	err := repo.Save(ctx, mem)
+
+	var alarmErr alarm.ErrAlarmActive
+	if !errors.As(err, &alarmErr) {
+		t.Fatalf("Save error = %v; want alarm.ErrAlarmActive", err)
+	}
+	if alarmErr.Type != repository.AlarmNoSpace {
+		t.Errorf("ErrAlarmActive.Type = %v; want %v", alarmErr.Type, repository.AlarmNoSpace)
+	}
+}
@@ -0,0 +1,202 @@
+// Package admin exposes a small HTTP API for memory operations and
+// Prometheus metrics, intended for operational use rather than end users.
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/lexlapax/cogmem/internal/domain/entity"
+	"github.com/lexlapax/cogmem/internal/domain/repository"
+)
+
+// PingFunc checks connectivity to the underlying storage backend, backing the
+// /health endpoint.
+type PingFunc func(ctx context.Context) error
+
+// Server exposes REST endpoints for memory operations alongside /metrics and
+// /health. Every /memories request must carry X-User-ID (and optionally
+// X-Entity-ID) headers, which populate the request's entity.PartitionContext.
+type Server struct {
+	repo      repository.EpisodicRepository
+	authToken string
+	ping      PingFunc
+}
+
+// NewServer constructs an admin Server. authToken, if non-empty, is required
+// as a Bearer token on every /memories request. ping, if non-nil, backs the
+// /health endpoint.
+func NewServer(repo repository.EpisodicRepository, authToken string, ping PingFunc) *Server {
+	return &Server{repo: repo, authToken: authToken, ping: ping}
+}
+
+// Handler returns the configured http.Handler for the admin API.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /health", s.handleHealth)
+	mux.Handle("GET /metrics", promhttp.Handler())
+	mux.HandleFunc("POST /memories", s.withAuth(s.handleCreate))
+	mux.HandleFunc("GET /memories/{id}", s.withAuth(s.handleGet))
+	mux.HandleFunc("GET /memories", s.withAuth(s.handleRecent))
+	mux.HandleFunc("POST /memories/search", s.withAuth(s.handleSearch))
+	return mux
+}
+
+// ListenAndServe starts the admin HTTP server on addr, blocking until it returns
+// an error.
+func (s *Server) ListenAndServe(addr string) error {
+	return http.ListenAndServe(addr, s.Handler())
+}
+
+// withAuth rejects requests lacking a matching Bearer token, when authToken is set.
+func (s *Server) withAuth(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.authToken != "" && r.Header.Get("Authorization") != "Bearer "+s.authToken {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// partitionFromHeaders builds an entity.PartitionContext from X-User-ID/X-Entity-ID.
+func partitionFromHeaders(r *http.Request) (entity.PartitionContext, error) {
+	userIDHeader := r.Header.Get("X-User-ID")
+	if userIDHeader == "" {
+		return entity.PartitionContext{}, errors.New("missing X-User-ID header")
+	}
+	userID, err := uuid.Parse(userIDHeader)
+	if err != nil {
+		return entity.PartitionContext{}, fmt.Errorf("invalid X-User-ID header: %w", err)
+	}
+	pCtx := entity.PartitionContext{UserID: userID}
+	if entityIDHeader := r.Header.Get("X-Entity-ID"); entityIDHeader != "" {
+		entityID, err := uuid.Parse(entityIDHeader)
+		if err != nil {
+			return entity.PartitionContext{}, fmt.Errorf("invalid X-Entity-ID header: %w", err)
+		}
+		pCtx.EntityID = &entityID
+	}
+	return pCtx, nil
+}
+
+// createRequest is the POST /memories request body.
+type createRequest struct {
+	Content    string    `json:"content"`
+	Embedding  []float32 `json:"embedding"`
+	ShareScope string    `json:"share_scope"`
+}
+
+func (s *Server) handleCreate(w http.ResponseWriter, r *http.Request) {
+	pCtx, err := partitionFromHeaders(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var req createRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	mem := entity.NewEpisodicMemory(pCtx.UserID, pCtx.EntityID, req.Content, req.Embedding, time.Now().UTC(), req.ShareScope)
+	if err := s.repo.Save(r.Context(), mem); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusCreated, mem)
+}
+
+func (s *Server) handleGet(w http.ResponseWriter, r *http.Request) {
+	pCtx, err := partitionFromHeaders(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+	mem, err := s.repo.FindByID(r.Context(), id, pCtx)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, mem)
+}
+
+func (s *Server) handleRecent(w http.ResponseWriter, r *http.Request) {
+	pCtx, err := partitionFromHeaders(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	recentParam := r.URL.Query().Get("recent")
+	if recentParam == "" {
+		http.Error(w, "missing recent query parameter", http.StatusBadRequest)
+		return
+	}
+	limit, err := strconv.Atoi(recentParam)
+	if err != nil || limit <= 0 {
+		http.Error(w, "invalid recent query parameter", http.StatusBadRequest)
+		return
+	}
+	mems, err := s.repo.FindRecent(r.Context(), limit, pCtx)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusOK, mems)
+}
+
+// searchRequest is the POST /memories/search request body.
+type searchRequest struct {
+	Vector []float32 `json:"vector"`
+	Limit  int       `json:"limit"`
+}
+
+func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	pCtx, err := partitionFromHeaders(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var req searchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.Limit <= 0 {
+		req.Limit = 10
+	}
+	mems, err := s.repo.FindByVector(r.Context(), req.Vector, req.Limit, pCtx)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusOK, mems)
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	if s.ping != nil {
+		if err := s.ping(r.Context()); err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
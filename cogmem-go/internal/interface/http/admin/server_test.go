@@ -0,0 +1,132 @@
+package admin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/lexlapax/cogmem/internal/domain/entity"
+	"github.com/lexlapax/cogmem/internal/domain/repository"
+)
+
+// fakeRepository is a minimal in-memory repository.EpisodicRepository used to
+// exercise the admin Server without a real storage backend.
+type fakeRepository struct {
+	mems map[uuid.UUID]*entity.EpisodicMemory
+}
+
+func newFakeRepository() *fakeRepository {
+	return &fakeRepository{mems: map[uuid.UUID]*entity.EpisodicMemory{}}
+}
+
+func (r *fakeRepository) Save(ctx context.Context, mem *entity.EpisodicMemory) error {
+	r.mems[mem.ID] = mem
+	return nil
+}
+func (r *fakeRepository) FindByID(ctx context.Context, id uuid.UUID, pCtx entity.PartitionContext) (*entity.EpisodicMemory, error) {
+	mem, ok := r.mems[id]
+	if !ok {
+		return nil, errNotFound
+	}
+	return mem, nil
+}
+func (r *fakeRepository) FindByVector(ctx context.Context, vector []float32, limit int, pCtx entity.PartitionContext) ([]*entity.EpisodicMemory, error) {
+	var results []*entity.EpisodicMemory
+	for _, mem := range r.mems {
+		results = append(results, mem)
+	}
+	return results, nil
+}
+func (r *fakeRepository) FindRecent(ctx context.Context, limit int, pCtx entity.PartitionContext) ([]*entity.EpisodicMemory, error) {
+	var results []*entity.EpisodicMemory
+	for _, mem := range r.mems {
+		results = append(results, mem)
+	}
+	return results, nil
+}
+func (r *fakeRepository) UpdateAccessibility(ctx context.Context, id uuid.UUID, score float64, pCtx entity.PartitionContext) error {
+	return nil
+}
+func (r *fakeRepository) Delete(ctx context.Context, id uuid.UUID, pCtx entity.PartitionContext) error {
+	delete(r.mems, id)
+	return nil
+}
+func (r *fakeRepository) IteratePartitions(ctx context.Context, batchSize int, fn repository.PartitionBatchFunc) error {
+	return nil
+}
+
+var _ repository.EpisodicRepository = (*fakeRepository)(nil)
+
+var errNotFound = errNotFoundError{}
+
+type errNotFoundError struct{}
+
+func (errNotFoundError) Error() string { return "not found" }
+
+func TestServerCreateAndGet(t *testing.T) {
+	repo := newFakeRepository()
+	server := NewServer(repo, "", nil)
+
+	userID := uuid.New()
+	body, _ := json.Marshal(createRequest{Content: "hello", Embedding: []float32{0.1}, ShareScope: "user"})
+	req := httptest.NewRequest(http.MethodPost, "/memories", bytes.NewReader(body))
+	req.Header.Set("X-User-ID", userID.String())
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create status = %d; want %d, body=%s", rec.Code, http.StatusCreated, rec.Body.String())
+	}
+	var created entity.EpisodicMemory
+	if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil {
+		t.Fatalf("decode created: %v", err)
+	}
+
+	getReq := httptest.NewRequest(http.MethodGet, "/memories/"+created.ID.String(), nil)
+	getReq.Header.Set("X-User-ID", userID.String())
+	getRec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(getRec, getReq)
+
+	if getRec.Code != http.StatusOK {
+		t.Fatalf("get status = %d; want %d, body=%s", getRec.Code, http.StatusOK, getRec.Body.String())
+	}
+}
+
+func TestServerRequiresUserIDHeader(t *testing.T) {
+	server := NewServer(newFakeRepository(), "", nil)
+	req := httptest.NewRequest(http.MethodGet, "/memories?recent=10", nil)
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d; want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestServerRequiresAuthToken(t *testing.T) {
+	server := NewServer(newFakeRepository(), "secret", nil)
+	req := httptest.NewRequest(http.MethodGet, "/memories?recent=10", nil)
+	req.Header.Set("X-User-ID", uuid.New().String())
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d; want %d", rec.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestServerHealth(t *testing.T) {
+	server := NewServer(newFakeRepository(), "", func(ctx context.Context) error { return nil })
+	req := httptest.NewRequest(http.MethodGet, "/health", nil)
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("status = %d; want %d", rec.Code, http.StatusOK)
+	}
+}
@@ -2,7 +2,7 @@ package integration
 
 import (
    "context"
-   "os"
+   "sort"
    "testing"
    "time"
 
@@ -11,6 +11,7 @@ import (
 
    "github.com/lexlapax/cogmem/internal/domain/entity"
    "github.com/lexlapax/cogmem/internal/infrastructure/config"
+   "github.com/lexlapax/cogmem/internal/infrastructure/persistence/migrate"
    "github.com/lexlapax/cogmem/internal/infrastructure/persistence/postgres"
 )
 
@@ -35,13 +36,9 @@ func TestPostgresEpisodicRepository_SaveFind(t *testing.T) {
        t.Fatalf("pgxpool connect failed: %v", err)
    }
    defer pool.Close()
-   // Apply migration
-   sqlBytes, err := os.ReadFile("../../migrations/0001_create_episodic_memory_table.sql")
-   if err != nil {
-       t.Fatalf("read migration: %v", err)
-   }
-   if _, err := pool.Exec(ctx, string(sqlBytes)); err != nil {
-       t.Fatalf("apply migration: %v", err)
+   // Apply migrations
+   if err := migrate.Run(ctx, pool); err != nil {
+       t.Fatalf("run migrations: %v", err)
    }
    // Clear table
    if _, err := pool.Exec(ctx, "TRUNCATE episodic_memory"); err != nil {
@@ -114,4 +111,81 @@ func TestPostgresEpisodicRepository_SaveFind(t *testing.T) {
    if vecResults[0].ID != mem1.ID {
        t.Errorf("FindByVector returned ID %v; want %v", vecResults[0].ID, mem1.ID)
    }
+}
+
+// TestPostgresEpisodicRepository_IteratePartitionsSurvivesMidIterationDelete verifies
+// that deleting a batch's rows from within the IteratePartitions callback (as
+// decay.Decayer.RunOnce does) does not cause later rows in the same partition to be
+// skipped, which offset-based pagination is prone to.
+func TestPostgresEpisodicRepository_IteratePartitionsSurvivesMidIterationDelete(t *testing.T) {
+   cfg, err := config.LoadConfig()
+   if err != nil {
+       t.Fatalf("config load failed: %v", err)
+   }
+   ctx := context.Background()
+   var pool *pgxpool.Pool
+   for i := 0; i < 10; i++ {
+       pool, err = pgxpool.New(ctx, cfg.DatabaseURL)
+       if err == nil {
+           break
+       }
+       time.Sleep(1 * time.Second)
+   }
+   if err != nil {
+       t.Fatalf("pgxpool connect failed: %v", err)
+   }
+   defer pool.Close()
+   if err := migrate.Run(ctx, pool); err != nil {
+       t.Fatalf("run migrations: %v", err)
+   }
+   if _, err := pool.Exec(ctx, "TRUNCATE episodic_memory"); err != nil {
+       t.Fatalf("truncate table: %v", err)
+   }
+   repo := postgres.NewPostgresEpisodicRepository(pool)
+
+   userID := uuid.New()
+   now := time.Now().UTC().Truncate(time.Second)
+   dim := cfg.EmbeddingDim
+   const total = 5
+   mems := make([]*entity.EpisodicMemory, total)
+   for i := 0; i < total; i++ {
+       vec := make([]float32, dim)
+       mem := entity.NewEpisodicMemory(userID, nil, "mem", vec, now, "user")
+       if err := repo.Save(ctx, mem); err != nil {
+           t.Fatalf("save mem %d: %v", i, err)
+       }
+       mems[i] = mem
+   }
+
+   // Sort by ID to match IteratePartitions' ORDER BY id, so deleting the first batch's
+   // rows exercises the same shift-of-later-rows hazard offset pagination had.
+   sort.Slice(mems, func(i, j int) bool { return mems[i].ID.String() < mems[j].ID.String() })
+
+   visited := map[uuid.UUID]bool{}
+   const batchSize = 2
+   deleted := 0
+   err = repo.IteratePartitions(ctx, batchSize, func(ctx context.Context, batchPCtx entity.PartitionContext, batch []*entity.EpisodicMemory) error {
+       for _, mem := range batch {
+           visited[mem.ID] = true
+           if deleted < 2 {
+               if err := repo.Delete(ctx, mem.ID, batchPCtx); err != nil {
+                   return err
+               }
+               deleted++
+           }
+       }
+       return nil
+   })
+   if err != nil {
+       t.Fatalf("IteratePartitions error: %v", err)
+   }
+
+   for _, mem := range mems {
+       if !visited[mem.ID] {
+           t.Errorf("memory %s was never visited by IteratePartitions", mem.ID)
+       }
+   }
+   if len(visited) != total {
+       t.Errorf("visited %d distinct memories; want %d", len(visited), total)
+   }
 }
\ No newline at end of file